@@ -1,22 +1,211 @@
 package main
 
 import (
-	"brew/internal/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
+
+	"brew/internal/adapters/clock"
+	"brew/internal/adapters/handlers"
+	"brew/internal/adapters/identifier"
+	"brew/internal/adapters/labels"
+	"brew/internal/adapters/qr"
+	"brew/internal/adapters/repositories/memory"
+	"brew/internal/adapters/repositories/sqlite"
+	"brew/internal/core/ports"
+	"brew/internal/core/services"
+	"brew/internal/utils/config"
+	"brew/internal/utils/logger"
 )
 
+const configPath = "http-config.json"
+
 func main() {
 	logger.Info("Starting brew HTTP server")
-	logger.Info("HTTP server ready")
 
-	counter := 0
-	for {
-		counter++
+	err := run()
+	if err != nil {
+		logger.Error("HTTP server stopped with error", "error", err)
+	}
+
+	logger.Info("HTTP server stopped")
+	logger.Shutdown()
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Settings are read once; changing them takes a restart. The logger
+	// watches the file for the log level on its own.
+	cfg := config.Load(configPath)
+
+	repos, err := openRepositories(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRepositories(repos.closers...)
+
+	systemClock := clock.NewSystemClock()
+	qrService := services.NewQRService(qr.NewGenerator(cfg.PublicURL))
+	auditService := services.NewAuditService(repos.audit, repos.transactor, systemClock)
+	brewService := services.NewBrewService(
+		repos.brews,
+		repos.records,
+		repos.transfers,
+		repos.sessions,
+		identifier.NewCrockfordGenerator(),
+		qrService,
+		auditService,
+		services.NewSearchService(repos.search),
+		systemClock,
+	)
+	sessionService := services.NewSessionService(repos.sessions, auditService, systemClock)
+	timelineService := services.NewTimelineService(repos.timeline, brewService)
+	qualityService := services.NewQualityService(repos.quality, repos.records, brewService)
+	labelService := services.NewLabelService(brewService, qrService, labels.NewSVGRenderer())
+	syncService := services.NewSyncService(repos.sync, brewService, timelineService, qualityService)
+
+	routes := handlers.NewServer(
+		brewService,
+		sessionService,
+		qrService,
+		timelineService,
+		qualityService,
+		labelService,
+		syncService,
+	).Routes()
 
-		logger.Info("Application running", "iteration", counter, "status", "healthy")
-		logger.Debug("Debug message for troubleshooting", "iteration", counter, "timestamp", time.Now().Format(time.RFC3339))
-		logger.Error("Simulated error for testing", "iteration", counter, "error_type", "test_error")
+	server := &http.Server{
+		Addr:              cfg.HTTPAddress,
+		Handler:           routes,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	reaper := services.NewSessionReaper(
+		repos.sessions,
+		auditService,
+		systemClock,
+		cfg.SessionTTL(),
+		services.ReapMode(cfg.ReaperMode),
+		cfg.ReaperDryRun,
+	)
+	reaperCtx, stopReaper := context.WithCancel(ctx)
+	reaperDone := make(chan struct{})
+	go func() {
+		defer close(reaperDone)
+		reaper.Run(reaperCtx, cfg.ReaperInterval())
+	}()
+	// The reaper must finish its pass before the repositories close.
+	defer func() {
+		stopReaper()
+		<-reaperDone
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("HTTP server ready", "address", cfg.HTTPAddress)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		stop()
+	}
+
+	logger.Info("Shutting down HTTP server", "timeout", cfg.ShutdownTimeout().String())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout())
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to drain in-flight requests", "error", err)
+		server.Close()
+		return err
+	}
+	return nil
+}
+
+type repositories struct {
+	brews      ports.BrewRepository
+	records    ports.BrewRecordRepository
+	transfers  ports.TransferRepository
+	timeline   ports.TimelineRepository
+	quality    ports.QualityRepository
+	audit      ports.AuditRepository
+	sync       ports.SyncRepository
+	search     ports.SearchRepository
+	sessions   ports.SessionRepository
+	transactor ports.Transactor
+	closers    []any
+}
+
+func openRepositories(ctx context.Context, cfg *config.Config) (*repositories, error) {
+	switch cfg.StorageDriver {
+	case config.StorageDriverMemory:
+		logger.Warn("Using in-memory storage, data will be lost on exit")
+		brews := memory.NewBrewRepository()
+		records := memory.NewBrewRecordRepository()
+		return &repositories{
+			brews:      brews,
+			records:    records,
+			transfers:  memory.NewTransferRepository(brews, records),
+			timeline:   memory.NewTimelineRepository(),
+			quality:    memory.NewQualityRepository(),
+			audit:      memory.NewAuditRepository(),
+			sync:       memory.NewSyncRepository(),
+			search:     memory.NewSearchRepository(),
+			sessions:   memory.NewSessionRepository(),
+			transactor: memory.NewTransactor(),
+		}, nil
+	case config.StorageDriverSQLite:
+		db, err := sqlite.Open(ctx, cfg.DatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		return &repositories{
+			brews:      sqlite.NewBrewRepository(db),
+			records:    sqlite.NewBrewRecordRepository(db),
+			transfers:  sqlite.NewTransferRepository(db),
+			timeline:   sqlite.NewTimelineRepository(db),
+			quality:    sqlite.NewQualityRepository(db),
+			audit:      sqlite.NewAuditRepository(db),
+			sync:       sqlite.NewSyncRepository(db),
+			search:     sqlite.NewSearchRepository(db),
+			sessions:   sqlite.NewSessionRepository(db),
+			transactor: sqlite.NewTransactor(db),
+			closers:    []any{db},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
 
-		time.Sleep(1 * time.Second)
+// closeRepositories flushes every repository that holds resources needing
+// an explicit close, such as open database handles.
+func closeRepositories(repositories ...any) {
+	for _, repository := range repositories {
+		closer, ok := repository.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			logger.Error("Failed to close repository", "error", err)
+		}
 	}
 }
@@ -0,0 +1,21 @@
+package clock
+
+import (
+	"time"
+
+	"brew/internal/core/ports"
+)
+
+var _ ports.Clock = (*SystemClock)(nil)
+
+// SystemClock reads the wall clock in UTC, the zone stored times are read
+// back in.
+type SystemClock struct{}
+
+func NewSystemClock() *SystemClock {
+	return &SystemClock{}
+}
+
+func (c *SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+type auditEntryResponse struct {
+	ID         string                `json:"id"`
+	SessionID  string                `json:"session_id,omitempty"`
+	ShareToken string                `json:"share_token,omitempty"`
+	Operation  domain.AuditOperation `json:"operation"`
+	BrewID     string                `json:"brew_id,omitempty"`
+	RecordID   string                `json:"record_id,omitempty"`
+	At         time.Time             `json:"at"`
+}
+
+type auditListResponse struct {
+	Items       []auditEntryResponse `json:"items"`
+	TotalCount  int                  `json:"total_count"`
+	NextPointer *string              `json:"next_pointer,omitempty"`
+	HasMore     bool                 `json:"has_more"`
+}
+
+func newAuditListResponse(result *ports.PaginatedResult[*domain.AuditEntry]) auditListResponse {
+	response := auditListResponse{
+		Items:       make([]auditEntryResponse, 0, len(result.Items)),
+		TotalCount:  result.TotalCount,
+		NextPointer: result.NextPointer,
+		HasMore:     result.HasMore,
+	}
+	for _, entry := range result.Items {
+		response.Items = append(response.Items, auditEntryResponse{
+			ID:         entry.ID,
+			SessionID:  entry.SessionID,
+			ShareToken: entry.ShareToken,
+			Operation:  entry.Operation,
+			BrewID:     entry.BrewID,
+			RecordID:   entry.RecordID,
+			At:         entry.At,
+		})
+	}
+	return response
+}
+
+func (s *Server) listBrewAudit(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	var pointer *string
+	if value := r.URL.Query().Get("pointer"); value != "" {
+		pointer = &value
+	}
+
+	result, err := s.brewService.ListAuditEntries(r.Context(), r.PathValue("id"), sessionID, pointer, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAuditListResponse(result))
+}
+
+func (s *Server) listSessionAudit(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionOwner(w, r)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	var pointer *string
+	if value := r.URL.Query().Get("pointer"); value != "" {
+		pointer = &value
+	}
+
+	result, err := s.sessionService.ListAuditEntries(r.Context(), sessionID, pointer, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAuditListResponse(result))
+}
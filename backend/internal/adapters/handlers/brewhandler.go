@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type createBrewRequest struct {
+	Name string `json:"name"`
+}
+
+// updateBrewRequest is a partial edit: absent fields are kept, and an
+// empty value clears an optional detail.
+type updateBrewRequest struct {
+	Name         *string          `json:"name"`
+	VesselVolume *quantityPayload `json:"vessel_volume"`
+	Location     *string          `json:"location"`
+	ColorTag     *string          `json:"color_tag"`
+	ScobyOrigin  *string          `json:"scoby_origin"`
+}
+
+type brewResponse struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	VesselVolume *quantityPayload `json:"vessel_volume,omitempty"`
+	Location     string           `json:"location,omitempty"`
+	ColorTag     string           `json:"color_tag,omitempty"`
+	ScobyOrigin  string           `json:"scoby_origin,omitempty"`
+	ArchivedAt   *time.Time       `json:"archived_at,omitempty"`
+	NextActionAt *time.Time       `json:"next_action_at,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	Version      int64            `json:"version"`
+}
+
+type brewListResponse struct {
+	Items       []brewResponse `json:"items"`
+	TotalCount  int            `json:"total_count"`
+	NextPointer *string        `json:"next_pointer,omitempty"`
+	HasMore     bool           `json:"has_more"`
+}
+
+type brewDetailsResponse struct {
+	brewResponse
+	LatestRecord *annotatedRecordResponse `json:"latest_record,omitempty"`
+	History      []recordResponse         `json:"history"`
+}
+
+func newBrewResponse(brew *domain.Brew) brewResponse {
+	response := brewResponse{
+		ID:           brew.ID,
+		Name:         brew.Name,
+		Location:     brew.Location,
+		ColorTag:     string(brew.ColorTag),
+		ScobyOrigin:  brew.ScobyOrigin,
+		ArchivedAt:   brew.ArchivedAt,
+		NextActionAt: brew.NextActionAt,
+		CreatedAt:    brew.CreatedAt,
+		UpdatedAt:    brew.UpdatedAt,
+		Version:      brew.Version,
+	}
+	if !brew.VesselVolume.IsZero() {
+		volume := newQuantityPayload(brew.VesselVolume)
+		response.VesselVolume = &volume
+	}
+	return response
+}
+
+func (req updateBrewRequest) toDomain() domain.BrewChanges {
+	changes := domain.BrewChanges{
+		Name:        req.Name,
+		Location:    req.Location,
+		ScobyOrigin: req.ScobyOrigin,
+	}
+	if req.VesselVolume != nil {
+		volume := req.VesselVolume.toDomain()
+		changes.VesselVolume = &volume
+	}
+	if req.ColorTag != nil {
+		tag := domain.ColorTag(*req.ColorTag)
+		changes.ColorTag = &tag
+	}
+	return changes
+}
+
+func (s *Server) createBrew(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req createBrewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeServiceError(w, domain.InvalidField("name", "is required"))
+		return
+	}
+
+	brew, err := s.brewService.CreateBrew(r.Context(), req.Name, sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	setETag(w, brew.Version)
+	writeJSON(w, http.StatusCreated, newBrewResponse(brew))
+}
+
+func (s *Server) getBrew(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	brew, err := s.brewService.GetBrew(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	setETag(w, brew.Version)
+	writeJSON(w, http.StatusOK, newBrewResponse(brew))
+}
+
+// updateBrew renames the jar or edits its details. Clients send the ETag
+// they last saw as If-Match so that two brewers editing the same jar cannot
+// overwrite each other unnoticed.
+func (s *Server) updateBrew(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	expectedVersion, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateBrewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	brew, err := s.brewService.UpdateBrew(r.Context(), r.PathValue("id"), sessionID, req.toDomain(), expectedVersion)
+	if err != nil {
+		writeConditionalError(w, r, err)
+		return
+	}
+	setETag(w, brew.Version)
+	writeJSON(w, http.StatusOK, newBrewResponse(brew))
+}
+
+func (s *Server) archiveBrew(w http.ResponseWriter, r *http.Request) {
+	s.setBrewArchived(w, r, s.brewService.ArchiveBrew)
+}
+
+func (s *Server) unarchiveBrew(w http.ResponseWriter, r *http.Request) {
+	s.setBrewArchived(w, r, s.brewService.UnarchiveBrew)
+}
+
+func (s *Server) setBrewArchived(
+	w http.ResponseWriter,
+	r *http.Request,
+	edit func(ctx context.Context, id string, sessionID string, expectedVersion *int64) (*domain.Brew, error),
+) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	expectedVersion, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	brew, err := edit(r.Context(), r.PathValue("id"), sessionID, expectedVersion)
+	if err != nil {
+		writeConditionalError(w, r, err)
+		return
+	}
+	setETag(w, brew.Version)
+	writeJSON(w, http.StatusOK, newBrewResponse(brew))
+}
+
+func (s *Server) listBrews(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	query, ok := parseBrewQuery(w, r)
+	if !ok {
+		return
+	}
+	query.SessionID = sessionID
+	query.Limit = limit
+
+	result, err := s.brewService.QueryBrews(r.Context(), query)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := brewListResponse{
+		Items:       make([]brewResponse, 0, len(result.Items)),
+		TotalCount:  result.TotalCount,
+		NextPointer: result.NextPointer,
+		HasMore:     result.HasMore,
+	}
+	for _, brew := range result.Items {
+		response.Items = append(response.Items, newBrewResponse(brew))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// parseBrewQuery reads ?q=&status=&sort=&order=&pointer= of a jar list;
+// the service validates the values.
+func parseBrewQuery(w http.ResponseWriter, r *http.Request) (domain.BrewQuery, bool) {
+	values := r.URL.Query()
+	query := domain.BrewQuery{
+		Search: values.Get("q"),
+		Status: domain.BrewStatus(values.Get("status")),
+		Sort:   domain.BrewSort(values.Get("sort")),
+	}
+	if value := values.Get("pointer"); value != "" {
+		query.Pointer = &value
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		writeServiceError(w, domain.InvalidField("order", "must be asc or desc"))
+		return domain.BrewQuery{}, false
+	}
+	return query, true
+}
+
+func parseLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return defaultPageLimit, true
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxPageLimit {
+		writeServiceError(w, domain.InvalidField("limit", "must be between 1 and "+strconv.Itoa(maxPageLimit)))
+		return 0, false
+	}
+	return limit, true
+}
+
+// lookupBrew is the scan-to-view call: the body is a label photo, a
+// scanned deep link or a hand-typed code.
+func (s *Server) lookupBrew(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	data, ok := readScan(w, r)
+	if !ok {
+		return
+	}
+
+	details, err := s.brewService.LookupBrew(r.Context(), data, sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := brewDetailsResponse{
+		brewResponse: newBrewResponse(details.Brew),
+		History:      make([]recordResponse, 0, len(details.History)),
+	}
+	if details.LatestRecord != nil {
+		latest := newAnnotatedRecordResponse(details.LatestRecord)
+		response.LatestRecord = &latest
+	}
+	for _, record := range details.History {
+		response.History = append(response.History, newRecordResponse(record))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"brew/internal/core/domain"
+)
+
+type labelPresetResponse struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	PageWidth   float64 `json:"page_width_mm"`
+	PageHeight  float64 `json:"page_height_mm"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	LabelWidth  float64 `json:"label_width_mm"`
+	LabelHeight float64 `json:"label_height_mm"`
+}
+
+func (s *Server) listLabelPresets(w http.ResponseWriter, r *http.Request) {
+	presets := domain.LabelPresets()
+
+	response := make([]labelPresetResponse, 0, len(presets))
+	for _, preset := range presets {
+		response = append(response, labelPresetResponse{
+			Name:        preset.Name,
+			Description: preset.Description,
+			PageWidth:   preset.PageWidth,
+			PageHeight:  preset.PageHeight,
+			Columns:     preset.Columns,
+			Rows:        preset.Rows,
+			LabelWidth:  preset.LabelWidth,
+			LabelHeight: preset.LabelHeight,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// getLabelSheet returns one SVG page per request; X-Label-Pages tells the
+// client how many pages the print job has.
+func (s *Server) getLabelSheet(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	skip, ok := parseIntQuery(w, r, "skip", 0)
+	if !ok {
+		return
+	}
+	page, ok := parseIntQuery(w, r, "page", 1)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	pages, err := s.labelService.GenerateLabelSheet(r.Context(), sessionID, query["brew_id"], query.Get("preset"), skip)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if page < 1 || page > len(pages) {
+		writeError(w, http.StatusNotFound, "page "+strconv.Itoa(page)+" of "+strconv.Itoa(len(pages))+" does not exist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("X-Label-Pages", strconv.Itoa(len(pages)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pages[page-1])
+}
+
+func parseIntQuery(w http.ResponseWriter, r *http.Request, name string, fallback int) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, true
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		writeServiceError(w, domain.InvalidField(name, "must be a number"))
+		return 0, false
+	}
+	return parsed, true
+}
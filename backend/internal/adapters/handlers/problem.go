@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"brew/internal/core/domain"
+	"brew/internal/utils/logger"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemResponse is the body of every error response, shaped after
+// RFC 9457. Code is stable and meant for programs; Detail is for people and
+// may change wording at any time.
+type problemResponse struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Code   string         `json:"code"`
+	Detail string         `json:"detail,omitempty"`
+	Fields []fieldProblem `json:"fields,omitempty"`
+}
+
+type fieldProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type problemKind struct {
+	target error
+	status int
+	code   string
+}
+
+// problemKinds maps the domain error catalogue to HTTP. Order matters:
+// the first kind an error matches wins.
+var problemKinds = []problemKind{
+	{target: domain.ErrInvalid, status: http.StatusBadRequest, code: "invalid"},
+	{target: domain.ErrNotFound, status: http.StatusNotFound, code: "not_found"},
+	{target: domain.ErrAlreadyExists, status: http.StatusConflict, code: "already_exists"},
+	{target: domain.ErrConflict, status: http.StatusConflict, code: "conflict"},
+	{target: domain.ErrImmutable, status: http.StatusConflict, code: "immutable"},
+	{target: domain.ErrSessionInactive, status: http.StatusForbidden, code: "session_inactive"},
+	{target: domain.ErrForbidden, status: http.StatusForbidden, code: "forbidden"},
+}
+
+// statusCodes names the problems handlers report without a domain error,
+// e.g. a missing header.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "invalid",
+	http.StatusForbidden:           "forbidden",
+	http.StatusNotFound:            "not_found",
+	http.StatusConflict:            "conflict",
+	http.StatusPreconditionFailed:  "precondition_failed",
+	http.StatusUnprocessableEntity: "unprocessable",
+}
+
+func writeProblem(w http.ResponseWriter, problem problemResponse) {
+	problem.Type = "urn:brew:problem:" + problem.Code
+	problem.Title = http.StatusText(problem.Status)
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		logger.Error("Failed to encode problem response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	code, ok := statusCodes[status]
+	if !ok {
+		code = "internal"
+	}
+	writeProblem(w, problemResponse{Status: status, Code: code, Detail: message})
+}
+
+// writeServiceError is the single place domain errors become HTTP
+// responses. Anything outside the catalogue is logged and hidden behind a
+// generic 500 so internals never leak to clients.
+func writeServiceError(w http.ResponseWriter, err error) {
+	for _, kind := range problemKinds {
+		if !errors.Is(err, kind.target) {
+			continue
+		}
+		problem := problemResponse{Status: kind.status, Code: kind.code, Detail: err.Error()}
+		var invalid *domain.ValidationError
+		if errors.As(err, &invalid) {
+			for _, field := range invalid.Fields {
+				problem.Fields = append(problem.Fields, fieldProblem{Field: field.Field, Message: field.Message})
+			}
+		}
+		writeProblem(w, problem)
+		return
+	}
+
+	logger.Error("Request failed", "error", err)
+	writeProblem(w, problemResponse{
+		Status: http.StatusInternalServerError,
+		Code:   "internal",
+		Detail: "internal server error",
+	})
+}
+
+// writeConditionalError answers a failed If-Match with 412 rather than the
+// 409 an unconditional write that lost a race gets.
+func writeConditionalError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrConflict) && r.Header.Get(ifMatchHeader) != "" {
+		writeError(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	writeServiceError(w, err)
+}
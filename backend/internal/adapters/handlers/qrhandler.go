@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+// maxQRImageBytes leaves room for phone photos of printed labels.
+const maxQRImageBytes = 10 << 20
+
+var qrContentTypes = map[ports.QRFormat]string{
+	ports.QRFormatPNG: "image/png",
+	ports.QRFormatSVG: "image/svg+xml",
+}
+
+type parseQRCodeResponse struct {
+	BrewID string `json:"brew_id"`
+}
+
+func (s *Server) generateQRCode(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	format := ports.QRFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = ports.QRFormatPNG
+	}
+	contentType, ok := qrContentTypes[format]
+	if !ok {
+		writeServiceError(w, domain.InvalidField("format", "must be png or svg"))
+		return
+	}
+
+	brew, err := s.brewService.GetBrew(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	image, err := s.qrService.GenerateQRCode(r.Context(), brew.ID, format)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+func (s *Server) parseQRCode(w http.ResponseWriter, r *http.Request) {
+	data, ok := readScan(w, r)
+	if !ok {
+		return
+	}
+
+	brewID, err := s.qrService.ParseQRCode(r.Context(), data)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, parseQRCodeResponse{BrewID: brewID})
+}
+
+// readScan takes a label photo or decoded payload either as the raw
+// request body or as the "image" field of a multipart form.
+func readScan(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body := http.MaxBytesReader(w, r.Body, maxQRImageBytes)
+
+	var source io.Reader = body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		r.Body = body
+		file, _, err := r.FormFile("image")
+		if err != nil {
+			writeServiceError(w, domain.InvalidField("image", "is required"))
+			return nil, false
+		}
+		defer file.Close()
+		source = file
+	}
+
+	data, err := io.ReadAll(source)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type addEvaluationRequest struct {
+	Rating      int      `json:"rating"`
+	Sweetness   int      `json:"sweetness"`
+	Fizz        int      `json:"fizz"`
+	PH          *float64 `json:"ph"`
+	Notes       string   `json:"notes"`
+	Suggestions string   `json:"suggestions"`
+}
+
+type evaluationResponse struct {
+	ID          string    `json:"id"`
+	RecordID    string    `json:"record_id"`
+	BrewID      string    `json:"brew_id"`
+	Rating      int       `json:"rating"`
+	Sweetness   int       `json:"sweetness"`
+	Fizz        int       `json:"fizz"`
+	PH          *float64  `json:"ph,omitempty"`
+	Notes       string    `json:"notes"`
+	Suggestions string    `json:"suggestions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type qualityMetricsResponse struct {
+	Count            int        `json:"count"`
+	AverageRating    float64    `json:"average_rating"`
+	AverageSweetness float64    `json:"average_sweetness"`
+	AverageFizz      float64    `json:"average_fizz"`
+	AveragePH        *float64   `json:"average_ph,omitempty"`
+	RatingTrend      float64    `json:"rating_trend"`
+	FirstAt          *time.Time `json:"first_at,omitempty"`
+	LastAt           *time.Time `json:"last_at,omitempty"`
+}
+
+type recipeQualityResponse struct {
+	Key     string                 `json:"key"`
+	Recipe  recipePayload          `json:"recipe"`
+	BrewIDs []string               `json:"brew_ids"`
+	Metrics qualityMetricsResponse `json:"metrics"`
+}
+
+func newEvaluationResponse(evaluation *domain.QualityEvaluation) evaluationResponse {
+	return evaluationResponse{
+		ID:          evaluation.ID,
+		RecordID:    evaluation.RecordID,
+		BrewID:      evaluation.BrewID,
+		Rating:      evaluation.Score.Rating,
+		Sweetness:   evaluation.Score.Sweetness,
+		Fizz:        evaluation.Score.Fizz,
+		PH:          evaluation.Score.PH,
+		Notes:       evaluation.Notes,
+		Suggestions: evaluation.Suggestions,
+		CreatedAt:   evaluation.CreatedAt,
+	}
+}
+
+func newQualityMetricsResponse(metrics *domain.QualityMetrics) qualityMetricsResponse {
+	return qualityMetricsResponse{
+		Count:            metrics.Count,
+		AverageRating:    metrics.AverageRating,
+		AverageSweetness: metrics.AverageSweetness,
+		AverageFizz:      metrics.AverageFizz,
+		AveragePH:        metrics.AveragePH,
+		RatingTrend:      metrics.RatingTrend,
+		FirstAt:          metrics.FirstAt,
+		LastAt:           metrics.LastAt,
+	}
+}
+
+func (s *Server) addEvaluation(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req addEvaluationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	score := domain.QualityScore{
+		Rating:    req.Rating,
+		Sweetness: req.Sweetness,
+		Fizz:      req.Fizz,
+		PH:        req.PH,
+	}
+	evaluation, err := s.qualityService.AddEvaluation(
+		r.Context(),
+		r.PathValue("id"),
+		sessionID,
+		score,
+		req.Notes,
+		req.Suggestions,
+	)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newEvaluationResponse(evaluation))
+}
+
+func (s *Server) listEvaluations(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	evaluations, err := s.qualityService.ListEvaluations(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := make([]evaluationResponse, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		response = append(response, newEvaluationResponse(evaluation))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getJarQuality(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	metrics, err := s.qualityService.JarMetrics(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newQualityMetricsResponse(metrics))
+}
+
+func (s *Server) getRecipeQuality(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	recipes, err := s.qualityService.RecipeMetrics(r.Context(), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := make([]recipeQualityResponse, 0, len(recipes))
+	for _, recipe := range recipes {
+		response = append(response, recipeQualityResponse{
+			Key:     recipe.Key,
+			Recipe:  newRecipePayload(recipe.Recipe),
+			BrewIDs: recipe.BrewIDs,
+			Metrics: newQualityMetricsResponse(&recipe.Metrics),
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type quantityPayload struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+type ingredientPayload struct {
+	Name     string          `json:"name"`
+	Quantity quantityPayload `json:"quantity"`
+}
+
+type recipePayload struct {
+	Water     quantityPayload     `json:"water"`
+	SugarType string              `json:"sugar_type"`
+	Sugar     quantityPayload     `json:"sugar"`
+	TeaType   string              `json:"tea_type"`
+	Tea       quantityPayload     `json:"tea"`
+	Extras    []ingredientPayload `json:"extras"`
+}
+
+type recordResponse struct {
+	ID          string        `json:"id"`
+	BrewID      string        `json:"brew_id"`
+	Recipe      recipePayload `json:"recipe"`
+	CreatedAt   time.Time     `json:"created_at"`
+	SubmittedAt *time.Time    `json:"submitted_at,omitempty"`
+}
+
+type appendNoteRequest struct {
+	Text string `json:"text"`
+}
+
+type noteResponse struct {
+	ID        string    `json:"id"`
+	RecordID  string    `json:"record_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type annotatedRecordResponse struct {
+	recordResponse
+	Notes []noteResponse `json:"notes"`
+}
+
+type recordListResponse struct {
+	Items       []recordResponse `json:"items"`
+	TotalCount  int              `json:"total_count"`
+	NextPointer *string          `json:"next_pointer,omitempty"`
+	HasMore     bool             `json:"has_more"`
+}
+
+func (q quantityPayload) toDomain() domain.Quantity {
+	return domain.Quantity{Amount: q.Amount, Unit: domain.Unit(q.Unit)}
+}
+
+func newQuantityPayload(q domain.Quantity) quantityPayload {
+	return quantityPayload{Amount: q.Amount, Unit: string(q.Unit)}
+}
+
+func (p recipePayload) toDomain() domain.Recipe {
+	recipe := domain.Recipe{
+		Water:     p.Water.toDomain(),
+		SugarType: domain.SugarType(p.SugarType),
+		Sugar:     p.Sugar.toDomain(),
+		TeaType:   domain.TeaType(p.TeaType),
+		Tea:       p.Tea.toDomain(),
+	}
+	for _, extra := range p.Extras {
+		recipe.Extras = append(recipe.Extras, domain.Ingredient{
+			Name:     extra.Name,
+			Quantity: extra.Quantity.toDomain(),
+		})
+	}
+	return recipe
+}
+
+func newRecipePayload(recipe domain.Recipe) recipePayload {
+	payload := recipePayload{
+		Water:     newQuantityPayload(recipe.Water),
+		SugarType: string(recipe.SugarType),
+		Sugar:     newQuantityPayload(recipe.Sugar),
+		TeaType:   string(recipe.TeaType),
+		Tea:       newQuantityPayload(recipe.Tea),
+		Extras:    make([]ingredientPayload, 0, len(recipe.Extras)),
+	}
+	for _, extra := range recipe.Extras {
+		payload.Extras = append(payload.Extras, ingredientPayload{
+			Name:     extra.Name,
+			Quantity: newQuantityPayload(extra.Quantity),
+		})
+	}
+	return payload
+}
+
+func newRecordResponse(record *domain.BrewRecord) recordResponse {
+	return recordResponse{
+		ID:          record.ID,
+		BrewID:      record.BrewID,
+		Recipe:      newRecipePayload(record.Recipe),
+		CreatedAt:   record.CreatedAt,
+		SubmittedAt: record.SubmittedAt,
+	}
+}
+
+func newNoteResponse(note *domain.RecordNote) noteResponse {
+	return noteResponse{
+		ID:        note.ID,
+		RecordID:  note.RecordID,
+		Text:      note.Text,
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+func newAnnotatedRecordResponse(annotated *domain.AnnotatedRecord) annotatedRecordResponse {
+	response := annotatedRecordResponse{
+		recordResponse: newRecordResponse(annotated.Record),
+		Notes:          make([]noteResponse, 0, len(annotated.Notes)),
+	}
+	for _, note := range annotated.Notes {
+		response.Notes = append(response.Notes, newNoteResponse(note))
+	}
+	return response
+}
+
+func (s *Server) addRecord(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req recipePayload
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	record, err := s.brewService.AddRecord(r.Context(), r.PathValue("id"), sessionID, req.toDomain())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newRecordResponse(record))
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	var pointer *string
+	if value := r.URL.Query().Get("pointer"); value != "" {
+		pointer = &value
+	}
+
+	result, err := s.brewService.ListRecords(r.Context(), r.PathValue("id"), sessionID, pointer, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := recordListResponse{
+		Items:       make([]recordResponse, 0, len(result.Items)),
+		TotalCount:  result.TotalCount,
+		NextPointer: result.NextPointer,
+		HasMore:     result.HasMore,
+	}
+	for _, record := range result.Items {
+		response.Items = append(response.Items, newRecordResponse(record))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	annotated, err := s.brewService.GetRecord(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAnnotatedRecordResponse(annotated))
+}
+
+func (s *Server) appendNote(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req appendNoteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		writeServiceError(w, domain.InvalidField("text", "is required"))
+		return
+	}
+
+	note, err := s.brewService.AppendNote(r.Context(), r.PathValue("id"), sessionID, req.Text)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newNoteResponse(note))
+}
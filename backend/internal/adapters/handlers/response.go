@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"brew/internal/core/services"
+	"brew/internal/utils/logger"
+)
+
+const maxRequestBodyBytes = 1 << 20
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("Failed to encode response", "error", err)
+	}
+}
+
+// setETag publishes an entity version for clients to send back in If-Match.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// parseIfMatch returns the version an If-Match header expects, or nil when
+// the request is unconditional. Weak tags are accepted since versions are
+// the only validators this API hands out.
+func parseIfMatch(w http.ResponseWriter, r *http.Request) (*int64, bool) {
+	value := strings.TrimSpace(r.Header.Get(ifMatchHeader))
+	if value == "" || value == "*" {
+		return nil, true
+	}
+
+	tag := strings.TrimPrefix(value, "W/")
+	version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64)
+	if err != nil || len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		writeError(w, http.StatusBadRequest, ifMatchHeader+" must be a single ETag returned by this API")
+		return nil, false
+	}
+	return &version, true
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// requireSession names the session a request acts on: the one behind the
+// share token when one was presented, otherwise the caller's own.
+func requireSession(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if grant := services.ShareGrantFrom(r.Context()); grant != nil {
+		return grant.SessionID, true
+	}
+	sessionID := r.Header.Get(sessionHeader)
+	if sessionID == "" {
+		writeError(w, http.StatusBadRequest, sessionHeader+" header is required")
+		return "", false
+	}
+	return sessionID, true
+}
+
+// requireSessionPath is requireSession for /sessions/{id} routes, whose path
+// must name that same session. Share token holders are never told the
+// session ID, since it is all the owner authenticates with, so they write
+// sharedSessionPath in its place.
+func requireSessionPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return "", false
+	}
+	id := r.PathValue("id")
+	if id == sharedSessionPath && services.ShareGrantFrom(r.Context()) != nil {
+		return sessionID, true
+	}
+	if sessionID != id {
+		writeError(w, http.StatusForbidden, "jars of another session are not accessible")
+		return "", false
+	}
+	return sessionID, true
+}
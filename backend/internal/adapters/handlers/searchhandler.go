@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type searchHitResponse struct {
+	Kind     domain.SearchKind `json:"kind"`
+	ID       string            `json:"id"`
+	BrewID   string            `json:"brew_id"`
+	RecordID string            `json:"record_id,omitempty"`
+	Text     string            `json:"text"`
+	At       time.Time         `json:"at"`
+	Score    float64           `json:"score"`
+}
+
+type searchResponse struct {
+	Items []searchHitResponse `json:"items"`
+}
+
+// search is GET /sessions/{id}/search?q=hibiscus&limit=: jar names, record
+// ingredients and notes matching every word of q, best match first.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionPath(w, r)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	hits, err := s.brewService.Search(r.Context(), sessionID, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := searchResponse{Items: make([]searchHitResponse, 0, len(hits))}
+	for _, hit := range hits {
+		response.Items = append(response.Items, searchHitResponse{
+			Kind:     hit.Document.Kind,
+			ID:       hit.Document.ID,
+			BrewID:   hit.Document.BrewID,
+			RecordID: hit.Document.RecordID,
+			Text:     hit.Document.Text,
+			At:       hit.Document.At,
+			Score:    hit.Score,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	"brew/internal/core/services"
+	"brew/internal/utils/logger"
+)
+
+const (
+	sessionHeader    = "X-Session-ID"
+	shareTokenHeader = "X-Share-Token"
+	ifMatchHeader    = "If-Match"
+
+	// sharedSessionPath addresses the session behind X-Share-Token in
+	// /sessions/{id} routes.
+	sharedSessionPath = "shared"
+)
+
+type Server struct {
+	brewService     *services.BrewService
+	sessionService  *services.SessionService
+	qrService       *services.QRService
+	timelineService *services.TimelineService
+	qualityService  *services.QualityService
+	labelService    *services.LabelService
+	syncService     *services.SyncService
+}
+
+func NewServer(
+	brewService *services.BrewService,
+	sessionService *services.SessionService,
+	qrService *services.QRService,
+	timelineService *services.TimelineService,
+	qualityService *services.QualityService,
+	labelService *services.LabelService,
+	syncService *services.SyncService,
+) *Server {
+	return &Server{
+		brewService:     brewService,
+		sessionService:  sessionService,
+		qrService:       qrService,
+		timelineService: timelineService,
+		qualityService:  qualityService,
+		labelService:    labelService,
+		syncService:     syncService,
+	}
+}
+
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /sessions", s.createSession)
+	mux.HandleFunc("GET /sessions/{id}", s.getSession)
+	mux.HandleFunc("GET /sessions/{id}/brews", s.listBrews)
+	mux.HandleFunc("GET /sessions/{id}/search", s.search)
+	mux.HandleFunc("GET /sessions/{id}/quality", s.getRecipeQuality)
+	mux.HandleFunc("GET /sessions/{id}/labels", s.getLabelSheet)
+	mux.HandleFunc("POST /sessions/{id}/share-tokens", s.createShareToken)
+	mux.HandleFunc("GET /sessions/{id}/share-tokens", s.listShareTokens)
+	mux.HandleFunc("DELETE /sessions/{id}/share-tokens/{token}", s.revokeShareToken)
+	mux.HandleFunc("GET /sessions/{id}/audit", s.listSessionAudit)
+
+	mux.HandleFunc("GET /share", s.getShareGrant)
+
+	mux.HandleFunc("POST /brews", s.createBrew)
+	mux.HandleFunc("POST /brews/lookup", s.lookupBrew)
+	mux.HandleFunc("POST /brews/import", s.acceptTransfer)
+	mux.HandleFunc("GET /brews/{id}", s.getBrew)
+	mux.HandleFunc("PATCH /brews/{id}", s.updateBrew)
+	mux.HandleFunc("POST /brews/{id}/archive", s.archiveBrew)
+	mux.HandleFunc("DELETE /brews/{id}/archive", s.unarchiveBrew)
+	mux.HandleFunc("GET /brews/{id}/qr", s.generateQRCode)
+	mux.HandleFunc("POST /brews/{id}/records", s.addRecord)
+	mux.HandleFunc("GET /brews/{id}/records", s.listRecords)
+	mux.HandleFunc("POST /brews/{id}/timeline", s.addTimelineEvent)
+	mux.HandleFunc("GET /brews/{id}/timeline", s.listTimelineEvents)
+	mux.HandleFunc("GET /brews/{id}/next-action", s.getNextAction)
+	mux.HandleFunc("GET /brews/{id}/evaluations", s.listEvaluations)
+	mux.HandleFunc("GET /brews/{id}/quality", s.getJarQuality)
+	mux.HandleFunc("GET /brews/{id}/audit", s.listBrewAudit)
+	mux.HandleFunc("POST /brews/{id}/transfers", s.offerTransfer)
+
+	mux.HandleFunc("GET /records/{id}", s.getRecord)
+	mux.HandleFunc("POST /records/{id}/notes", s.appendNote)
+	mux.HandleFunc("POST /records/{id}/evaluations", s.addEvaluation)
+
+	mux.HandleFunc("POST /timeline/{id}/complete", s.completeTimelineEvent)
+
+	mux.HandleFunc("POST /qr/parse", s.parseQRCode)
+
+	mux.HandleFunc("GET /labels/presets", s.listLabelPresets)
+
+	mux.HandleFunc("POST /sync", s.sync)
+
+	return s.withShareToken(s.withSessionActivity(mux))
+}
+
+// withSessionActivity keeps sessions in use, directly or through a share
+// token, from being expired by the reaper.
+func (s *Server) withSessionActivity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(sessionHeader)
+		if grant := services.ShareGrantFrom(r.Context()); grant != nil {
+			sessionID = grant.SessionID
+		}
+		if sessionID != "" {
+			if err := s.sessionService.UpdateLastAccessed(r.Context(), sessionID); err != nil {
+				logger.Debug("Failed to record session activity", "error", err, "session_id", sessionID)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
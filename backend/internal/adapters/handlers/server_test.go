@@ -0,0 +1,1259 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"brew/internal/adapters/clock"
+	"brew/internal/adapters/labels"
+	"brew/internal/adapters/repositories/memory"
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/core/ports/mocks"
+	"brew/internal/core/services"
+)
+
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	counter := 0
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			counter++
+			return fmt.Sprintf("brew-%d", counter), nil
+		},
+		NormalizeFunc: func(ctx context.Context, identifier string) (string, error) {
+			identifier = strings.ToLower(identifier)
+			if !strings.HasPrefix(identifier, "brew-") {
+				return "", fmt.Errorf("identifier %s: %w", identifier, domain.ErrInvalid)
+			}
+			return identifier, nil
+		},
+	}
+	qrGenerator := &mocks.QRCodeGenerator{
+		GenerateQRCodeFunc: func(ctx context.Context, brewID string, format ports.QRFormat) ([]byte, error) {
+			return []byte(string(format) + ":" + brewID), nil
+		},
+		ParseQRCodeFunc: func(ctx context.Context, qrData []byte) (string, error) {
+			return string(qrData), nil
+		},
+	}
+
+	sessionRepo := memory.NewSessionRepository()
+	brewRepo := memory.NewBrewRepository()
+	recordRepo := memory.NewBrewRecordRepository()
+	qrService := services.NewQRService(qrGenerator)
+	systemClock := clock.NewSystemClock()
+	auditService := services.NewAuditService(memory.NewAuditRepository(), memory.NewTransactor(), systemClock)
+	brewService := services.NewBrewService(
+		brewRepo,
+		recordRepo,
+		memory.NewTransferRepository(brewRepo, recordRepo),
+		sessionRepo,
+		identifierGen,
+		qrService,
+		auditService,
+		services.NewSearchService(memory.NewSearchRepository()),
+		systemClock,
+	)
+	sessionService := services.NewSessionService(sessionRepo, auditService, systemClock)
+	timelineService := services.NewTimelineService(memory.NewTimelineRepository(), brewService)
+	qualityService := services.NewQualityService(memory.NewQualityRepository(), recordRepo, brewService)
+	labelService := services.NewLabelService(brewService, qrService, labels.NewSVGRenderer())
+	syncService := services.NewSyncService(memory.NewSyncRepository(), brewService, timelineService, qualityService)
+
+	return NewServer(
+		brewService,
+		sessionService,
+		qrService,
+		timelineService,
+		qualityService,
+		labelService,
+		syncService,
+	).Routes()
+}
+
+func doRequest(
+	t *testing.T,
+	handler http.Handler,
+	method string,
+	path string,
+	sessionID string,
+	body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	if sessionID != "" {
+		req.Header.Set(sessionHeader, sessionID)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func createSession(t *testing.T, handler http.Handler, id string) {
+	t.Helper()
+
+	rec := doRequest(t, handler, http.MethodPost, "/sessions", "", `{"id":"`+id+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /sessions status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestServer_CreateAndGetBrew(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"Скубі"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /brews status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	var created brewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "brew-1" || created.Name != "Скубі" {
+		t.Fatalf("POST /brews response = %+v", created)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /brews/brew-1 status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestServer_SessionIsolation(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-2", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("GET foreign brew status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/brews", "session-2", "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("GET foreign brew list status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_GetSession_OnlyForOwner(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+
+	rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET own session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	for _, caller := range []string{"session-2", "session-404"} {
+		rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1", caller, "")
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("GET session as %s status = %d, want %d", caller, rec.Code, http.StatusForbidden)
+		}
+	}
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1", "", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("GET session without %s status = %d, want %d", sessionHeader, rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/sessions/session-1/share-tokens", "session-1", `{"scope":"read-write"}`)
+	var token shareTokenResponse
+	if err := json.NewDecoder(rec.Body).Decode(&token); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, path := range []string{"/sessions/session-1", "/sessions/shared"} {
+		rec = doShareRequest(t, handler, http.MethodGet, path, token.Token, "")
+		if rec.Code != http.StatusForbidden || strings.Contains(rec.Body.String(), "session-1") {
+			t.Errorf("GET %s with a share token = %d %s, want %d", path, rec.Code, rec.Body, http.StatusForbidden)
+		}
+	}
+}
+
+func TestServer_CreateSession_ValidatesID(t *testing.T) {
+	handler := newTestServer(t)
+
+	for _, id := range []string{"", strings.Repeat("a", 100_000), "session 1", "сесія", "../brews", "shared"} {
+		rec := doRequest(t, handler, http.MethodPost, "/sessions", "", `{"id":"`+id+`"}`)
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"id"`) {
+			t.Errorf("POST /sessions id %.20q = %d %s, want %d on field id", id, rec.Code, rec.Body, http.StatusBadRequest)
+		}
+	}
+	createSession(t, handler, "7f9c2b1e-4d3a-4b8e-9c61-0a2f5d8e1b47")
+}
+
+func TestServer_CreateBrew_UnknownSession(t *testing.T) {
+	handler := newTestServer(t)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews", "missing", `{"name":"jar"}`)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("POST /brews status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_CreateBrew_MissingSessionHeader(t *testing.T) {
+	handler := newTestServer(t)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews", "", `{"name":"test"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST /brews status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_GetBrew_NotFound(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+
+	rec := doRequest(t, handler, http.MethodGet, "/brews/missing", "session-1", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("GET /brews/missing status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_ListBrews_Pagination(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+
+	for i := 0; i < 3; i++ {
+		doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+	}
+	doRequest(t, handler, http.MethodPost, "/brews", "session-2", `{"name":"other"}`)
+
+	rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1/brews?limit=2", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET brews status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var page brewListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Items) != 2 || !page.HasMore || page.NextPointer == nil || page.TotalCount != 3 {
+		t.Fatalf("first page = %+v", page)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/brews?limit=2&pointer="+*page.NextPointer, "session-1", "")
+	page = brewListResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Items) != 1 || page.HasMore {
+		t.Fatalf("second page = %+v", page)
+	}
+}
+
+func TestServer_QRCodeRoundTrip(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodGet, "/brews/brew-1/qr", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET qr status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "image/png" {
+		t.Fatalf("Content-Type = %s, want image/png", got)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/qr?format=svg", "session-1", "")
+	if got := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || got != "image/svg+xml" {
+		t.Fatalf("GET svg qr status = %d, Content-Type = %s", rec.Code, got)
+	}
+	if rec.Body.String() != "svg:brew-1" {
+		t.Fatalf("GET svg qr body = %s, want svg:brew-1", rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/qr?format=gif", "session-1", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("GET gif qr status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/qr/parse", "session-1", "brew-1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /qr/parse status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var parsed parseQRCodeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&parsed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if parsed.BrewID != "brew-1" {
+		t.Fatalf("parsed brew id = %s, want brew-1", parsed.BrewID)
+	}
+}
+
+func TestServer_AddAndListRecords(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	body := `{
+		"water": {"amount": 3, "unit": "l"},
+		"sugar_type": "cane",
+		"sugar": {"amount": 240, "unit": "g"},
+		"tea_type": "black",
+		"tea": {"amount": 2, "unit": "tbsp"},
+		"extras": [{"name": "каркаде", "quantity": {"amount": 10, "unit": "g"}}]
+	}`
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST records status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-2", body)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("POST records from other session status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/records", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET records status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var page recordListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("GET records items = %d, want 1", len(page.Items))
+	}
+	recipe := page.Items[0].Recipe
+	if recipe.SugarType != "cane" || len(recipe.Extras) != 1 || recipe.Extras[0].Name != "каркаде" {
+		t.Fatalf("GET records recipe = %+v", recipe)
+	}
+}
+
+func TestServer_RecordNotes(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1", `{"water": {"amount": 1, "unit": "l"}}`)
+	var record recordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if record.SubmittedAt == nil {
+		t.Fatal("POST records response is not submitted")
+	}
+
+	for _, text := range []string{"fizzy", "bottled"} {
+		rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/notes", "session-1", `{"text":"`+text+`"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST notes status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+		}
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/notes", "session-1", `{"text":"  "}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST empty note status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/records/"+record.ID, "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET record status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var annotated annotatedRecordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&annotated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if annotated.ID != record.ID || len(annotated.Notes) != 2 || annotated.Notes[0].Text != "fizzy" {
+		t.Fatalf("GET record = %+v", annotated)
+	}
+}
+
+func TestServer_TimelineAndNextAction(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodGet, "/brews/brew-1/next-action", "session-1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("GET next-action on empty timeline status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	start := time.Now().Add(-2 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", "session-1", `{"type":"start","at":"`+start+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST timeline status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", "session-1", `{"type":"unknown","at":"`+start+`"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST timeline with unknown type status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/next-action", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET next-action status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var action nextActionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&action); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if action.Type != "estimated_harvest" || action.Overdue || action.Event != nil {
+		t.Fatalf("GET next-action = %+v", action)
+	}
+}
+
+func TestServer_QualityEvaluations(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1", `{"water": {"amount": 1, "unit": "l"}, "tea_type": "green"}`)
+	var record recordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	for _, body := range []string{
+		`{"rating": 3, "sweetness": 4, "fizz": 2, "ph": 3.4, "notes": "too sweet"}`,
+		`{"rating": 5, "sweetness": 3, "fizz": 4, "suggestions": "keep it"}`,
+	} {
+		rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/evaluations", "session-1", body)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST evaluations status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+		}
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/evaluations", "session-1", `{"rating": 0}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST invalid evaluation status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/quality", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET quality status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var metrics qualityMetricsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&metrics); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if metrics.Count != 2 || metrics.AverageRating != 4 || metrics.AveragePH == nil || *metrics.AveragePH != 3.4 {
+		t.Fatalf("GET quality = %+v", metrics)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/quality", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET recipe quality status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var recipes []recipeQualityResponse
+	if err := json.NewDecoder(rec.Body).Decode(&recipes); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(recipes) != 1 || recipes[0].Recipe.TeaType != "green" || recipes[0].Metrics.Count != 2 {
+		t.Fatalf("GET recipe quality = %+v", recipes)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/quality", "session-2", "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("GET recipe quality of other session status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_LabelSheet(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	for _, name := range []string{"Скубі", "Big Bertha", "third"} {
+		doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"`+name+`"}`)
+	}
+
+	rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1/labels?preset=avery-l7163&skip=12", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET labels status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "image/svg+xml" {
+		t.Fatalf("Content-Type = %s, want image/svg+xml", got)
+	}
+	if got := rec.Header().Get("X-Label-Pages"); got != "2" {
+		t.Fatalf("X-Label-Pages = %s, want 2", got)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Скубі") || !strings.Contains(body, "Big Bertha") || strings.Contains(body, "third") {
+		t.Fatalf("GET labels page 1 = %s", body)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/labels?preset=avery-l7163&skip=12&page=2", "session-1", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "third") {
+		t.Fatalf("GET labels page 2 status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/labels?brew_id=brew-2", "session-1", "")
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "Big Bertha") || strings.Contains(body, "Скубі") {
+		t.Fatalf("GET labels for one jar status = %d, body = %s", rec.Code, body)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/labels?preset=unknown", "session-1", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("GET labels with unknown preset status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-2/labels", "session-2", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("GET labels without jars status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_LookupBrew(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"Скубі"}`)
+	for _, amount := range []string{"1", "2"} {
+		doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1", `{"water": {"amount": `+amount+`, "unit": "l"}}`)
+	}
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/lookup", "session-1", "BREW-1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST lookup status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var details brewDetailsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&details); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if details.ID != "brew-1" || details.Name != "Скубі" || len(details.History) != 2 {
+		t.Fatalf("POST lookup = %+v", details)
+	}
+	if details.LatestRecord == nil || details.LatestRecord.Recipe.Water.Amount != 2 {
+		t.Fatalf("POST lookup latest record = %+v", details.LatestRecord)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/lookup", "session-1", "jar-7")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST lookup with mistyped code status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/lookup", "session-2", "brew-1")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("POST lookup of other session's jar status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func doShareRequest(
+	t *testing.T,
+	handler http.Handler,
+	method string,
+	path string,
+	token string,
+	body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set(shareTokenHeader, token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_ShareTokens(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/sessions/session-1/share-tokens", "session-2", `{"scope":"read-only"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST foreign share token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/sessions/session-1/share-tokens", "session-1", `{"scope":"read-only","expires_in_seconds":3600}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST share token status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	var token shareTokenResponse
+	if err := json.NewDecoder(rec.Body).Decode(&token); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if token.Token == "" || token.Scope != domain.ReadOnlyScope || token.ExpiresAt == nil {
+		t.Fatalf("POST share token response = %+v", token)
+	}
+
+	rec = doShareRequest(t, handler, http.MethodGet, "/share", token.Token, "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"scope":"read-only"`) {
+		t.Fatalf("GET /share status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = doShareRequest(t, handler, http.MethodGet, "/brews/brew-1", token.Token, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET shared brew status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	rec = doShareRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", token.Token, `{"type":"refill","at":"2025-06-01T10:00:00Z"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST with read-only token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = doShareRequest(t, handler, http.MethodPost, "/sessions/session-1/share-tokens", token.Token, `{"scope":"read-write"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST share token with a share token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = doRequest(t, handler, http.MethodDelete, "/sessions/session-1/share-tokens/"+token.Token, "session-1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE share token status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	rec = doShareRequest(t, handler, http.MethodGet, "/brews/brew-1", token.Token, "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("GET with revoked token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_ShareTokenNeverRevealsOwnerSession(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "owner-secret")
+	doRequest(t, handler, http.MethodPost, "/brews", "owner-secret", `{"name":"jar"}`)
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "owner-secret", `{
+		"water": {"amount": 1, "unit": "l"},
+		"sugar_type": "cane",
+		"sugar": {"amount": 80, "unit": "g"},
+		"tea_type": "black",
+		"tea": {"amount": 5, "unit": "g"}
+	}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST records status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	doRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", "owner-secret", `{"type":"refill","at":"2025-06-01T10:00:00Z"}`)
+
+	rec = doRequest(t, handler, http.MethodPost, "/sessions/owner-secret/share-tokens", "owner-secret", `{"scope":"read-only"}`)
+	var token shareTokenResponse
+	if err := json.NewDecoder(rec.Body).Decode(&token); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	for _, path := range []string{
+		"/share",
+		"/brews/brew-1",
+		"/brews/brew-1/records",
+		"/brews/brew-1/timeline",
+		"/brews/brew-1/next-action",
+		"/sessions/shared/brews",
+		"/sessions/shared/search?q=jar",
+	} {
+		rec := doShareRequest(t, handler, http.MethodGet, path, token.Token, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s with share token status = %d, want %d, body = %s", path, rec.Code, http.StatusOK, rec.Body)
+		}
+		if strings.Contains(rec.Body.String(), "owner-secret") {
+			t.Errorf("GET %s with share token reveals the owner session: %s", path, rec.Body)
+		}
+	}
+
+	rec = doShareRequest(t, handler, http.MethodGet, "/sessions/shared/brews", token.Token, "")
+	var page brewListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != "brew-1" {
+		t.Fatalf("GET shared brews = %+v, want brew-1", page)
+	}
+
+	for _, write := range []struct{ method, path, body string }{
+		{http.MethodPost, "/brews", `{"name":"mine now"}`},
+		{http.MethodPatch, "/brews/brew-1", `{"location":"балкон"}`},
+		{http.MethodPost, "/brews/brew-1/transfers", `{}`},
+		{http.MethodPost, "/sessions/shared/share-tokens", `{"scope":"read-write"}`},
+		{http.MethodGet, "/sessions/shared/share-tokens", ""},
+		{http.MethodDelete, "/sessions/shared/share-tokens/" + token.Token, ""},
+	} {
+		rec := doShareRequest(t, handler, write.method, write.path, token.Token, write.body)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s %s with read-only token status = %d, want %d", write.method, write.path, rec.Code, http.StatusForbidden)
+		}
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/shared/brews", "owner-secret", "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("GET /sessions/shared/brews without a share token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_AuditLog(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/sessions/session-1/share-tokens", "session-1", `{"scope":"read-write"}`)
+	var token shareTokenResponse
+	if err := json.NewDecoder(rec.Body).Decode(&token); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = doShareRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", token.Token, `{"type":"refill","at":"2025-06-01T10:00:00Z"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST shared timeline status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	rec = doShareRequest(t, handler, http.MethodGet, "/brews/brew-1/audit", token.Token, "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("GET audit with share token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/audit", "session-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET brew audit status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var page auditListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("GET brew audit items = %+v, want 2", page.Items)
+	}
+	if page.Items[0].Operation != domain.AuditBrewCreated || page.Items[0].ShareToken != "" {
+		t.Errorf("GET brew audit first entry = %+v, want the owner's brew.created", page.Items[0])
+	}
+	if page.Items[1].Operation != domain.AuditTimelineAdded || page.Items[1].ShareToken != token.Token {
+		t.Errorf("GET brew audit second entry = %+v, want a shared timeline.added", page.Items[1])
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/audit", "session-1", "")
+	page = auditListResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if page.TotalCount != 4 {
+		t.Fatalf("GET session audit total = %d, want 4 (session, brew, token, timeline)", page.TotalCount)
+	}
+}
+
+func TestServer_TransferBrew(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/transfers", "session-2", `{}`)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("POST foreign transfer status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/transfers", "session-1", "")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST transfer status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	var transfer transferResponse
+	if err := json.NewDecoder(rec.Body).Decode(&transfer); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/import", "session-1", `{"token":"`+transfer.Token+`"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST import into the same session status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/import", "session-2", `{"token":"`+transfer.Token+`"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"id":"brew-1"`) {
+		t.Fatalf("POST import status = %d, body = %s", rec.Code, rec.Body)
+	}
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-2", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET transferred brew by new owner status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-1", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("GET transferred brew by old owner status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/brews/import", "session-2", `{"token":"`+transfer.Token+`"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST import twice status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/audit", "session-2", "")
+	var page auditListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	operations := make([]domain.AuditOperation, 0, len(page.Items))
+	for _, entry := range page.Items {
+		operations = append(operations, entry.Operation)
+	}
+	want := []domain.AuditOperation{
+		domain.AuditBrewCreated,
+		domain.AuditTransferOffered,
+		domain.AuditBrewTransferredOut,
+		domain.AuditBrewTransferredIn,
+	}
+	if fmt.Sprint(operations) != fmt.Sprint(want) {
+		t.Fatalf("GET audit operations = %v, want %v", operations, want)
+	}
+}
+
+func TestServer_TransferBrewHandsOverRecords(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1", `{
+		"water": {"amount": 1, "unit": "l"},
+		"sugar_type": "cane",
+		"sugar": {"amount": 80, "unit": "g"},
+		"tea_type": "black",
+		"tea": {"amount": 5, "unit": "g"}
+	}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST records status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	var record recordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/transfers", "session-1", "")
+	var transfer transferResponse
+	if err := json.NewDecoder(rec.Body).Decode(&transfer); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/brews/import", "session-2", `{"token":"`+transfer.Token+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST import status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1/records", "session-2", "")
+	var page recordListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(page.Items) != 1 || page.Items[0].ID != record.ID {
+		t.Fatalf("GET records by new owner status = %d, page = %+v, want the record", rec.Code, page)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/notes", "session-2", `{"text":"now mine"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST note by new owner status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	rec = doRequest(t, handler, http.MethodGet, "/records/"+record.ID, "session-2", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "now mine") {
+		t.Fatalf("GET record by new owner status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	for _, path := range []string{"/brews/brew-1/records", "/records/" + record.ID} {
+		rec = doRequest(t, handler, http.MethodGet, path, "session-1", "")
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s by old owner status = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
+
+func TestServer_Sync(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+
+	createOp, recordOp, noteOp := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	body := `{"cursor":0,"operations":[
+		{"id":"` + createOp + `","type":"brew.create","name":"jar"},
+		{"id":"` + recordOp + `","type":"record.add","brew_id":"op:` + createOp + `","recipe":{"water":{"amount":1,"unit":"l"}}},
+		{"id":"` + noteOp + `","type":"note.append","record_id":"op:` + recordOp + `","text":"fizzy"}
+	]}`
+
+	sync := func(body string, path string) syncResponse {
+		t.Helper()
+		rec := doRequest(t, handler, http.MethodPost, path, "session-1", body)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("POST %s status = %d, want %d, body = %s", path, rec.Code, http.StatusOK, rec.Body)
+		}
+		var response syncResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	first := sync(body, "/sync")
+	if len(first.Results) != 3 {
+		t.Fatalf("POST /sync results = %+v, want 3", first.Results)
+	}
+	for _, result := range first.Results {
+		if result.Status != string(domain.SyncApplied) || result.EntityID == "" {
+			t.Fatalf("POST /sync result = %+v, want applied", result)
+		}
+	}
+	// session.created, brew.created, record.added and record.note_appended.
+	if first.Results[0].EntityID != "brew-1" || len(first.Changes) != 4 || first.Cursor != first.Changes[3].Seq {
+		t.Fatalf("POST /sync = %+v", first)
+	}
+
+	// The client lost the response and sends the same batch again.
+	replay := sync(body, "/sync")
+	for i, result := range replay.Results {
+		if result != first.Results[i] {
+			t.Fatalf("POST /sync replay result = %+v, want %+v", result, first.Results[i])
+		}
+	}
+	rec := doRequest(t, handler, http.MethodGet, "/brews/brew-1/records", "session-1", "")
+	if !strings.Contains(rec.Body.String(), `"total_count":1`) {
+		t.Fatalf("GET records after replay body = %s, want a single record", rec.Body)
+	}
+
+	caughtUp := sync(`{"cursor":`+strconv.FormatInt(first.Cursor, 10)+`}`, "/sync")
+	if len(caughtUp.Changes) != 0 || caughtUp.Cursor != first.Cursor || caughtUp.HasMore {
+		t.Fatalf("POST /sync from the latest cursor = %+v, want no changes", caughtUp)
+	}
+	paged := sync(`{"cursor":0}`, "/sync?limit=2")
+	if len(paged.Changes) != 2 || !paged.HasMore || paged.Cursor != first.Changes[1].Seq {
+		t.Fatalf("POST /sync?limit=2 = %+v, want two changes and more", paged)
+	}
+
+	rejected := sync(`{"operations":[{"id":"`+uuid.NewString()+`","type":"timeline.add","brew_id":"brew-1","event_type":"harvest-ish"}]}`, "/sync")
+	if rejected.Results[0].Status != string(domain.SyncRejected) || rejected.Results[0].Error == "" {
+		t.Fatalf("POST /sync invalid operation = %+v, want rejected", rejected.Results)
+	}
+
+	// A rename made offline against an outdated jar is not remembered: the
+	// client rebases it on the current version and sends it again.
+	renameOp := uuid.NewString()
+	rename := func(version int64) syncResultResponse {
+		t.Helper()
+		response := sync(`{"operations":[{"id":"`+renameOp+`","type":"brew.rename","brew_id":"brew-1","name":"renamed","version":`+
+			strconv.FormatInt(version, 10)+`}]}`, "/sync")
+		return response.Results[0]
+	}
+	conflict := rename(7)
+	if conflict.Status != string(domain.SyncConflict) || conflict.Version == nil || *conflict.Version != 0 {
+		t.Fatalf("POST /sync outdated rename = %+v, want a conflict at version 0", conflict)
+	}
+	if rebased := rename(*conflict.Version); rebased.Status != string(domain.SyncApplied) || rebased.EntityID != "brew-1" {
+		t.Fatalf("POST /sync rebased rename = %+v, want applied", rebased)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/sync", "session-1", `{"operations":[{"id":"not-a-uuid","type":"brew.create"}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST /sync malformed batch status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_RenameBrewIfMatch(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	rename := func(ifMatch string, name string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPatch, "/brews/brew-1", bytes.NewBufferString(`{"name":"`+name+`"}`))
+		req.Header.Set(sessionHeader, "session-1")
+		if ifMatch != "" {
+			req.Header.Set(ifMatchHeader, ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-1", "")
+	etag := rec.Header().Get("ETag")
+	if etag != `"0"` {
+		t.Fatalf("GET /brews/brew-1 ETag = %q, want %q", etag, `"0"`)
+	}
+
+	// Two brewers loaded the jar at the same version; the second rename loses.
+	rec = rename(etag, "Скубі")
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != `"1"` {
+		t.Fatalf("PATCH status = %d, ETag = %q, body = %s", rec.Code, rec.Header().Get("ETag"), rec.Body)
+	}
+	rec = rename(etag, "Ду")
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("PATCH with a stale If-Match status = %d, want %d", rec.Code, http.StatusPreconditionFailed)
+	}
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1", "session-1", "")
+	if !strings.Contains(rec.Body.String(), `"name":"Скубі"`) {
+		t.Fatalf("GET after a lost rename body = %s, want the first rename kept", rec.Body)
+	}
+
+	rec = rename(`W/"1"`, "Ду")
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != `"2"` {
+		t.Fatalf("PATCH with a weak If-Match status = %d, ETag = %q", rec.Code, rec.Header().Get("ETag"))
+	}
+	rec = rename("", "jar")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH without If-Match status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	rec = rename("1", "jar")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("PATCH with an unquoted If-Match status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	rec = rename(`"3"`, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("PATCH with an empty name status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_EditAndArchiveBrew(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"Скубі"}`)
+
+	rec := doRequest(t, handler, http.MethodPatch, "/brews/brew-1", "session-1",
+		`{"vessel_volume":{"amount":3,"unit":"l"},"location":"кухня","color_tag":"green","scoby_origin":"from the market"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH details status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var brew brewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&brew); err != nil {
+		t.Fatalf("failed to decode brew: %v", err)
+	}
+	if brew.Name != "Скубі" || brew.Location != "кухня" || brew.ColorTag != "green" ||
+		brew.VesselVolume == nil || brew.VesselVolume.Amount != 3 || brew.ScobyOrigin != "from the market" {
+		t.Fatalf("PATCH details = %+v", brew)
+	}
+
+	rec = doRequest(t, handler, http.MethodPatch, "/brews/brew-1", "session-1", `{"location":"","color_tag":""}`)
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"location"`) || strings.Contains(rec.Body.String(), `"color_tag"`) {
+		t.Fatalf("PATCH clearing details status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(t, handler, http.MethodPatch, "/brews/brew-1", "session-2", `{"location":"балкон"}`)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("PATCH foreign jar status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/archive", "session-1", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"archived_at"`) {
+		t.Fatalf("POST archive status = %d, body = %s", rec.Code, rec.Body)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/archive", "session-2", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("POST archive of a foreign jar status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	rec = doRequest(t, handler, http.MethodDelete, "/brews/brew-1/archive", "session-1", "")
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"archived_at"`) {
+		t.Fatalf("DELETE archive status = %d, body = %s", rec.Code, rec.Body)
+	}
+}
+
+func TestServer_ListBrewsFiltersAndSorts(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	for _, name := range []string{"Чайний гриб", "Зелений чай", "Kombucha"} {
+		doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"`+name+`"}`)
+	}
+	doRequest(t, handler, http.MethodPost, "/brews/brew-2/archive", "session-1", "")
+
+	list := func(query string) []string {
+		t.Helper()
+		rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1/brews?"+query, "session-1", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET brews?%s status = %d, body = %s", query, rec.Code, rec.Body)
+		}
+		var response brewListResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode brews: %v", err)
+		}
+		var ids []string
+		for _, brew := range response.Items {
+			ids = append(ids, brew.ID)
+		}
+		return ids
+	}
+
+	if got := list("status=active"); fmt.Sprint(got) != "[brew-1 brew-3]" {
+		t.Errorf("active brews = %v, want [brew-1 brew-3]", got)
+	}
+	if got := list("q=" + url.QueryEscape("ЧАИ")); fmt.Sprint(got) != "[brew-1 brew-2]" {
+		t.Errorf("brews matching ЧАИ = %v, want [brew-1 brew-2]", got)
+	}
+	if got := list("q=kombuhca"); fmt.Sprint(got) != "[brew-3]" {
+		t.Errorf("brews matching a typo = %v, want [brew-3]", got)
+	}
+	if got := list("sort=created&order=desc"); fmt.Sprint(got) != "[brew-3 brew-2 brew-1]" {
+		t.Errorf("newest brews first = %v, want [brew-3 brew-2 brew-1]", got)
+	}
+
+	for _, query := range []string{"order=sideways", "sort=name", "status=deleted"} {
+		rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1/brews?"+query, "session-1", "")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET brews?%s status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestServer_SearchFindsNamesIngredientsAndNotes(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"Весняний"}`)
+	doRequest(t, handler, http.MethodPost, "/brews", "session-2", `{"name":"Hibiscus"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1",
+		`{"water":{"amount":3,"unit":"l"},"extras":[{"name":"Hibiscus","quantity":{"amount":10,"unit":"g"}}]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST record status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var record recordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/notes", "session-1", `{"text":"Hibiscus made it pink"}`)
+	doRequest(t, handler, http.MethodPatch, "/brews/brew-1", "session-1", `{"name":"Рожевий"}`)
+
+	search := func(q string) searchResponse {
+		t.Helper()
+		rec := doRequest(t, handler, http.MethodGet, "/sessions/session-1/search?q="+url.QueryEscape(q), "session-1", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET search?q=%s status = %d, body = %s", q, rec.Code, rec.Body)
+		}
+		var response searchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode search: %v", err)
+		}
+		return response
+	}
+
+	hits := search("hibiscus").Items
+	if len(hits) != 2 {
+		t.Fatalf("search hibiscus = %+v, want the record and the note of brew-1", hits)
+	}
+	kinds := map[domain.SearchKind]bool{}
+	for _, hit := range hits {
+		if hit.BrewID != "brew-1" || hit.RecordID != record.ID {
+			t.Errorf("search hibiscus hit = %+v, want brew-1 and record %s", hit, record.ID)
+		}
+		kinds[hit.Kind] = true
+	}
+	if !kinds[domain.RecordSearchKind] || !kinds[domain.NoteSearchKind] {
+		t.Errorf("search hibiscus kinds = %v, want record and note", kinds)
+	}
+
+	if hits := search("рожевии").Items; len(hits) != 1 || hits[0].ID != "brew-1" || hits[0].Text != "Рожевий" {
+		t.Errorf("search for the new name = %+v, want brew-1", hits)
+	}
+	if hits := search("весняний").Items; len(hits) != 0 {
+		t.Errorf("search for the old name = %+v, want nothing", hits)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/search?q=%20", "session-1", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("GET search without words status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	rec = doRequest(t, handler, http.MethodGet, "/sessions/session-1/search?q=hibiscus", "session-2", "")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("GET search of another session status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_SearchFollowsTransferredJar(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	createSession(t, handler, "session-2")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"Весняний"}`)
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1",
+		`{"water":{"amount":3,"unit":"l"},"extras":[{"name":"Hibiscus","quantity":{"amount":10,"unit":"g"}}]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST record status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var record recordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&record); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/transfers", "session-1", "")
+	var transfer transferResponse
+	if err := json.NewDecoder(rec.Body).Decode(&transfer); err != nil {
+		t.Fatalf("failed to decode transfer: %v", err)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/brews/import", "session-2", `{"token":"`+transfer.Token+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST import status = %d, body = %s", rec.Code, rec.Body)
+	}
+	rec = doRequest(t, handler, http.MethodPost, "/records/"+record.ID+"/notes", "session-2", `{"text":"Hibiscus and mint next time"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST note by new owner status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	search := func(sessionID string, q string) []searchHitResponse {
+		t.Helper()
+		rec := doRequest(t, handler, http.MethodGet, "/sessions/"+sessionID+"/search?q="+url.QueryEscape(q), sessionID, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET search?q=%s as %s status = %d, body = %s", q, sessionID, rec.Code, rec.Body)
+		}
+		var response searchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode search: %v", err)
+		}
+		return response.Items
+	}
+
+	for _, q := range []string{"весняний", "hibiscus", "mint"} {
+		if hits := search("session-1", q); len(hits) != 0 {
+			t.Errorf("search %s by old owner = %+v, want nothing", q, hits)
+		}
+	}
+	if hits := search("session-2", "hibiscus"); len(hits) != 2 {
+		t.Errorf("search hibiscus by new owner = %+v, want the record and the note", hits)
+	}
+	if hits := search("session-2", "весняний"); len(hits) != 1 || hits[0].ID != "brew-1" {
+		t.Errorf("search the name by new owner = %+v, want brew-1", hits)
+	}
+}
+
+func TestServer_ProblemResponses(t *testing.T) {
+	handler := newTestServer(t)
+	createSession(t, handler, "session-1")
+	doRequest(t, handler, http.MethodPost, "/brews", "session-1", `{"name":"jar"}`)
+
+	decode := func(rec *httptest.ResponseRecorder) problemResponse {
+		t.Helper()
+		if contentType := rec.Header().Get("Content-Type"); contentType != problemContentType {
+			t.Fatalf("Content-Type = %q, want %q", contentType, problemContentType)
+		}
+		var problem problemResponse
+		if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+			t.Fatalf("failed to decode problem: %v", err)
+		}
+		if problem.Status != rec.Code || problem.Type != "urn:brew:problem:"+problem.Code || problem.Title == "" {
+			t.Fatalf("problem = %+v for status %d", problem, rec.Code)
+		}
+		return problem
+	}
+
+	rec := doRequest(t, handler, http.MethodPost, "/brews/brew-1/timeline", "session-1", `{"type":"custom"}`)
+	problem := decode(rec)
+	if rec.Code != http.StatusBadRequest || problem.Code != "invalid" {
+		t.Fatalf("POST invalid timeline event = %d %+v", rec.Code, problem)
+	}
+	if len(problem.Fields) != 1 || problem.Fields[0].Field != "title" {
+		t.Fatalf("POST invalid timeline event fields = %+v, want title", problem.Fields)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/brews/brew-1/records", "session-1",
+		`{"water":{"amount":3,"unit":"kg"},"extras":[{"name":" ","quantity":{"amount":5,"unit":"g"}}]}`)
+	problem = decode(rec)
+	if rec.Code != http.StatusBadRequest || len(problem.Fields) != 2 ||
+		problem.Fields[0].Field != "recipe.water.unit" || problem.Fields[1].Field != "recipe.extras[0].name" {
+		t.Fatalf("POST invalid recipe = %d %+v", rec.Code, problem)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/missing", "session-1", "")
+	if problem := decode(rec); rec.Code != http.StatusNotFound || problem.Code != "not_found" {
+		t.Fatalf("GET missing brew = %d %+v", rec.Code, problem)
+	}
+
+	rec = doRequest(t, handler, http.MethodPost, "/sessions", "", `{"id":"session-1"}`)
+	if problem := decode(rec); rec.Code != http.StatusConflict || problem.Code != "already_exists" {
+		t.Fatalf("POST duplicate session = %d %+v", rec.Code, problem)
+	}
+
+	rec = doRequest(t, handler, http.MethodGet, "/brews/brew-1", "", "")
+	if problem := decode(rec); rec.Code != http.StatusBadRequest || problem.Code != "invalid" || len(problem.Fields) != 0 {
+		t.Fatalf("GET without a session = %d %+v", rec.Code, problem)
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/services"
+)
+
+type createSessionRequest struct {
+	ID string `json:"id"`
+}
+
+type sessionResponse struct {
+	ID           string     `json:"id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastAccessed time.Time  `json:"last_accessed"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	IsActive     bool       `json:"is_active"`
+	Version      int64      `json:"version"`
+}
+
+func newSessionResponse(session *domain.Session) sessionResponse {
+	return sessionResponse{
+		ID:           session.ID,
+		CreatedAt:    session.CreatedAt,
+		LastAccessed: session.LastAccessed,
+		ExpiresAt:    session.ExpiresAt,
+		IsActive:     session.IsActive,
+		Version:      session.Version,
+	}
+}
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	var req createSessionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.ID == sharedSessionPath {
+		writeServiceError(w, domain.InvalidField("id", "is reserved"))
+		return
+	}
+
+	session, err := s.sessionService.CreateSession(r.Context(), req.ID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newSessionResponse(session))
+}
+
+// getSession is only for the owner: share token holders learn what they were
+// granted from GET /share, and nobody else may tell whether a session exists.
+func (s *Server) getSession(w http.ResponseWriter, r *http.Request) {
+	if services.ShareGrantFrom(r.Context()) != nil {
+		writeError(w, http.StatusForbidden, "only the session owner can read the session")
+		return
+	}
+	sessionID, ok := requireSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := s.sessionService.GetSessionByID(r.Context(), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	setETag(w, session.Version)
+	writeJSON(w, http.StatusOK, newSessionResponse(session))
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/services"
+)
+
+type createShareTokenRequest struct {
+	Scope            domain.ShareScope `json:"scope"`
+	ExpiresInSeconds int64             `json:"expires_in_seconds"`
+}
+
+type shareTokenResponse struct {
+	Token     string            `json:"token"`
+	Scope     domain.ShareScope `json:"scope"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	IsActive  bool              `json:"is_active"`
+}
+
+type shareGrantResponse struct {
+	Scope domain.ShareScope `json:"scope"`
+}
+
+func newShareTokenResponse(token domain.ShareToken) shareTokenResponse {
+	return shareTokenResponse{
+		Token:     token.Token,
+		Scope:     token.Scope,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		IsActive:  token.IsActive,
+	}
+}
+
+// withShareToken resolves X-Share-Token before routing, so every handler and
+// service below sees the grant and enforces its scope.
+func (s *Server) withShareToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(shareTokenHeader)
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		grant, err := s.sessionService.ResolveShareToken(r.Context(), token)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.WithShareGrant(r.Context(), grant)))
+	})
+}
+
+// requireSessionOwner guards the routes only the session itself may use:
+// share token management and the audit log.
+func requireSessionOwner(w http.ResponseWriter, r *http.Request) (string, bool) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return "", false
+	}
+	if services.ShareGrantFrom(r.Context()) != nil || sessionID != r.PathValue("id") {
+		writeError(w, http.StatusForbidden, "only the session owner can access this")
+		return "", false
+	}
+	return sessionID, true
+}
+
+func (s *Server) createShareToken(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req createShareTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Scope == "" {
+		req.Scope = domain.ReadOnlyScope
+	}
+	if req.ExpiresInSeconds < 0 {
+		writeServiceError(w, domain.InvalidField("expires_in_seconds", "must not be negative"))
+		return
+	}
+
+	token, err := s.sessionService.CreateShareToken(
+		r.Context(),
+		sessionID,
+		req.Scope,
+		time.Duration(req.ExpiresInSeconds)*time.Second,
+	)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newShareTokenResponse(*token))
+}
+
+func (s *Server) listShareTokens(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := s.sessionService.ListShareTokens(r.Context(), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := make([]shareTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, newShareTokenResponse(token))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) revokeShareToken(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.sessionService.RevokeShareToken(r.Context(), sessionID, r.PathValue("token")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getShareGrant tells a token holder what the token allows. It never names
+// the session: a shared link carries nothing but the token, and the holder
+// reaches the session's collections as /sessions/shared/....
+func (s *Server) getShareGrant(w http.ResponseWriter, r *http.Request) {
+	grant := services.ShareGrantFrom(r.Context())
+	if grant == nil {
+		writeError(w, http.StatusBadRequest, shareTokenHeader+" header is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, shareGrantResponse{
+		Scope: grant.Scope,
+	})
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type syncRequest struct {
+	Cursor     int64                  `json:"cursor"`
+	Operations []syncOperationPayload `json:"operations"`
+}
+
+// syncOperationPayload mirrors the bodies of the endpoints each operation
+// type stands for; IDs may be "op:<operation id>" references.
+type syncOperationPayload struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	BrewID   string `json:"brew_id"`
+	RecordID string `json:"record_id"`
+	EventID  string `json:"event_id"`
+
+	Name      string        `json:"name"`
+	Recipe    recipePayload `json:"recipe"`
+	Text      string        `json:"text"`
+	EventType string        `json:"event_type"`
+	Title     string        `json:"title"`
+	At        time.Time     `json:"at"`
+
+	Rating      int      `json:"rating"`
+	Sweetness   int      `json:"sweetness"`
+	Fizz        int      `json:"fizz"`
+	PH          *float64 `json:"ph"`
+	Notes       string   `json:"notes"`
+	Suggestions string   `json:"suggestions"`
+
+	Version *int64 `json:"version"`
+}
+
+type syncResultResponse struct {
+	OperationID string `json:"operation_id"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	EntityID    string `json:"entity_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Version     *int64 `json:"version,omitempty"`
+}
+
+type syncChangeResponse struct {
+	Seq       int64                 `json:"seq"`
+	Operation domain.AuditOperation `json:"operation"`
+	BrewID    string                `json:"brew_id,omitempty"`
+	RecordID  string                `json:"record_id,omitempty"`
+	At        time.Time             `json:"at"`
+}
+
+type syncResponse struct {
+	Results []syncResultResponse `json:"results"`
+	Changes []syncChangeResponse `json:"changes"`
+	Cursor  int64                `json:"cursor"`
+	HasMore bool                 `json:"has_more"`
+}
+
+func (p syncOperationPayload) toDomain() *domain.SyncOperation {
+	return &domain.SyncOperation{
+		ID:        p.ID,
+		Type:      domain.SyncOperationType(p.Type),
+		BrewID:    p.BrewID,
+		RecordID:  p.RecordID,
+		EventID:   p.EventID,
+		Name:      p.Name,
+		Recipe:    p.Recipe.toDomain(),
+		Text:      p.Text,
+		EventType: domain.TimelineEventType(p.EventType),
+		Title:     p.Title,
+		At:        p.At,
+		Score: domain.QualityScore{
+			Rating:    p.Rating,
+			Sweetness: p.Sweetness,
+			Fizz:      p.Fizz,
+			PH:        p.PH,
+		},
+		Notes:       p.Notes,
+		Suggestions: p.Suggestions,
+		Version:     p.Version,
+	}
+}
+
+func newSyncResponse(batch *domain.SyncBatch) syncResponse {
+	response := syncResponse{
+		Results: make([]syncResultResponse, 0, len(batch.Results)),
+		Changes: make([]syncChangeResponse, 0, len(batch.Changes)),
+		Cursor:  batch.Cursor,
+		HasMore: batch.HasMore,
+	}
+	for _, result := range batch.Results {
+		response.Results = append(response.Results, syncResultResponse{
+			OperationID: result.OperationID,
+			Type:        string(result.Type),
+			Status:      string(result.Status),
+			EntityID:    result.EntityID,
+			Error:       result.Error,
+			Version:     result.Version,
+		})
+	}
+	for _, entry := range batch.Changes {
+		response.Changes = append(response.Changes, syncChangeResponse{
+			Seq:       entry.Seq,
+			Operation: entry.Operation,
+			BrewID:    entry.BrewID,
+			RecordID:  entry.RecordID,
+			At:        entry.At,
+		})
+	}
+	return response
+}
+
+// sync applies the client's queued operations and answers with what changed
+// since its cursor. Operations rejected one by one still return 200; only a
+// malformed batch as a whole is refused.
+func (s *Server) sync(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	var req syncRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	operations := make([]*domain.SyncOperation, 0, len(req.Operations))
+	for _, operation := range req.Operations {
+		operations = append(operations, operation.toDomain())
+	}
+
+	batch, err := s.syncService.Sync(r.Context(), sessionID, operations, req.Cursor, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSyncResponse(batch))
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type addTimelineEventRequest struct {
+	Type  string    `json:"type"`
+	Title string    `json:"title"`
+	At    time.Time `json:"at"`
+}
+
+type timelineEventResponse struct {
+	ID          string     `json:"id"`
+	BrewID      string     `json:"brew_id"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	At          time.Time  `json:"at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type nextActionResponse struct {
+	Type    string                 `json:"type"`
+	DueAt   time.Time              `json:"due_at"`
+	Overdue bool                   `json:"overdue"`
+	Event   *timelineEventResponse `json:"event,omitempty"`
+}
+
+func newTimelineEventResponse(event *domain.TimelineEvent) timelineEventResponse {
+	return timelineEventResponse{
+		ID:          event.ID,
+		BrewID:      event.BrewID,
+		Type:        string(event.Type),
+		Title:       event.Title,
+		At:          event.At,
+		CompletedAt: event.CompletedAt,
+		CreatedAt:   event.CreatedAt,
+	}
+}
+
+func newNextActionResponse(action *domain.NextAction) nextActionResponse {
+	response := nextActionResponse{
+		Type:    string(action.Type),
+		DueAt:   action.DueAt,
+		Overdue: action.Overdue,
+	}
+	if action.Event != nil {
+		event := newTimelineEventResponse(action.Event)
+		response.Event = &event
+	}
+	return response
+}
+
+func (s *Server) addTimelineEvent(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req addTimelineEventRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	event, err := s.timelineService.AddEvent(
+		r.Context(),
+		r.PathValue("id"),
+		sessionID,
+		domain.TimelineEventType(req.Type),
+		req.Title,
+		req.At,
+	)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newTimelineEventResponse(event))
+}
+
+func (s *Server) listTimelineEvents(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := s.timelineService.ListEvents(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := make([]timelineEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, newTimelineEventResponse(event))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) completeTimelineEvent(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	event, err := s.timelineService.CompleteEvent(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newTimelineEventResponse(event))
+}
+
+func (s *Server) getNextAction(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	action, err := s.timelineService.NextAction(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if action == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, newNextActionResponse(action))
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"brew/internal/core/domain"
+)
+
+type transferResponse struct {
+	Token     string    `json:"token"`
+	BrewID    string    `json:"brew_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type acceptTransferRequest struct {
+	Token string `json:"token"`
+}
+
+func (s *Server) offerTransfer(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := s.brewService.OfferTransfer(r.Context(), r.PathValue("id"), sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, transferResponse{
+		Token:     transfer.Token,
+		BrewID:    transfer.BrewID,
+		ExpiresAt: transfer.ExpiresAt,
+	})
+}
+
+// acceptTransfer takes the token in the body rather than the path so it
+// does not end up in access logs before it is spent.
+func (s *Server) acceptTransfer(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req acceptTransferRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Token == "" {
+		writeServiceError(w, domain.InvalidField("token", "is required"))
+		return
+	}
+
+	brew, err := s.brewService.AcceptTransfer(r.Context(), req.Token, sessionID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newBrewResponse(brew))
+}
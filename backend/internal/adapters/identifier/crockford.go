@@ -0,0 +1,125 @@
+package identifier
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.IdentifierGenerator = (*CrockfordGenerator)(nil)
+
+// alphabet is Crockford's base32: no I, L, O or U, so codes read aloud or
+// copied from a smudged label cannot be confused.
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+const (
+	// dataSymbols carry 40 random bits, about a trillion codes.
+	dataSymbols = 8
+	groupSize   = 3
+	separator   = '-'
+)
+
+// CrockfordGenerator produces jar IDs such as "7KQ-2M9-XD4": eight random
+// Crockford base32 symbols followed by a check symbol that catches every
+// single mistyped symbol and every pair of swapped neighbours.
+type CrockfordGenerator struct{}
+
+func NewCrockfordGenerator() *CrockfordGenerator {
+	return &CrockfordGenerator{}
+}
+
+func (g *CrockfordGenerator) Generate(ctx context.Context, name string) (string, error) {
+	buf := make([]byte, dataSymbols)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	symbols := make([]byte, 0, dataSymbols+1)
+	for _, b := range buf {
+		symbols = append(symbols, alphabet[b&31])
+	}
+	symbols = append(symbols, checkSymbol(symbols))
+	return format(symbols), nil
+}
+
+// Validate accepts codes typed by hand: case, separators, spaces and the
+// look-alikes I, L and O are forgiven; a wrong check symbol is not.
+func (g *CrockfordGenerator) Validate(ctx context.Context, identifier string) error {
+	_, err := g.Normalize(ctx, identifier)
+	return err
+}
+
+// Normalize turns e.g. "7kq 2m9 xd4" into "7KQ-2M9-XD4".
+func (g *CrockfordGenerator) Normalize(ctx context.Context, identifier string) (string, error) {
+	symbols, err := symbolsOf(identifier)
+	if err != nil {
+		return "", err
+	}
+	if len(symbols) != dataSymbols+1 {
+		return "", fmt.Errorf("identifier must have %d symbols: %w", dataSymbols+1, domain.ErrInvalid)
+	}
+	if checkSymbol(symbols[:dataSymbols]) != symbols[dataSymbols] {
+		return "", fmt.Errorf("identifier %s has a wrong check symbol, probably a typo: %w", identifier, domain.ErrInvalid)
+	}
+	return format(symbols), nil
+}
+
+func symbolsOf(identifier string) ([]byte, error) {
+	if strings.TrimSpace(identifier) == "" {
+		return nil, fmt.Errorf("identifier is empty: %w", domain.ErrInvalid)
+	}
+
+	symbols := make([]byte, 0, len(identifier))
+	for _, r := range strings.ToUpper(identifier) {
+		switch r {
+		case separator, ' ':
+			continue
+		case 'I', 'L':
+			r = '1'
+		case 'O':
+			r = '0'
+		}
+		if r > 127 || strings.IndexByte(alphabet, byte(r)) < 0 {
+			return nil, fmt.Errorf("identifier contains %q: %w", r, domain.ErrInvalid)
+		}
+		symbols = append(symbols, byte(r))
+	}
+	return symbols, nil
+}
+
+// checkSymbol is a Damm check over GF(32): folding x -> 2x + symbol is a
+// totally anti-symmetric quasigroup, so every single substitution and every
+// swap of neighbouring symbols changes the result.
+func checkSymbol(symbols []byte) byte {
+	interim := 0
+	for _, symbol := range symbols {
+		interim = double(interim) ^ strings.IndexByte(alphabet, symbol)
+	}
+	// The check symbol c must satisfy 2*interim + c = 0, and in GF(32)
+	// every element is its own additive inverse.
+	return alphabet[double(interim)]
+}
+
+// double multiplies by x in GF(32) modulo x^5 + x^2 + 1.
+func double(v int) int {
+	v <<= 1
+	if v&32 != 0 {
+		v ^= 0b100101
+	}
+	return v
+}
+
+func format(symbols []byte) string {
+	var b strings.Builder
+	for i, symbol := range symbols {
+		if i > 0 && i%groupSize == 0 {
+			b.WriteByte(separator)
+		}
+		b.WriteByte(symbol)
+	}
+	return b.String()
+}
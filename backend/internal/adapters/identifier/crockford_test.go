@@ -0,0 +1,106 @@
+package identifier
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCrockfordGenerator_GenerateIsValid(t *testing.T) {
+	generator := NewCrockfordGenerator()
+	ctx := context.Background()
+	pattern := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{3}-[0-9A-HJKMNP-TV-Z]{3}-[0-9A-HJKMNP-TV-Z]{3}$`)
+
+	seen := make(map[string]bool)
+	for range 1000 {
+		id, err := generator.Generate(ctx, "Скубі")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if !pattern.MatchString(id) {
+			t.Fatalf("Generate() = %s, want XXX-XXX-XXX", id)
+		}
+		if err := generator.Validate(ctx, id); err != nil {
+			t.Fatalf("Validate(%s) error = %v", id, err)
+		}
+		if seen[id] {
+			t.Fatalf("Generate() repeated %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestCrockfordGenerator_ValidateForgivesFormatting(t *testing.T) {
+	generator := NewCrockfordGenerator()
+	ctx := context.Background()
+
+	id, err := generator.Generate(ctx, "jar")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	sloppy := []string{
+		strings.ToLower(id),
+		strings.ReplaceAll(id, "-", ""),
+		strings.ReplaceAll(id, "-", " "),
+		strings.NewReplacer("1", "l", "0", "O").Replace(id),
+	}
+	for _, input := range sloppy {
+		if err := generator.Validate(ctx, input); err != nil {
+			t.Errorf("Validate(%q) error = %v, want nil", input, err)
+		}
+		normalized, err := generator.Normalize(ctx, input)
+		if err != nil || normalized != id {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", input, normalized, err, id)
+		}
+	}
+}
+
+func TestCrockfordGenerator_ValidateCatchesTypos(t *testing.T) {
+	generator := NewCrockfordGenerator()
+	ctx := context.Background()
+
+	for range 100 {
+		id, err := generator.Generate(ctx, "jar")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		symbols := []byte(strings.ReplaceAll(id, "-", ""))
+
+		for i := range symbols {
+			for _, replacement := range []byte(alphabet) {
+				if replacement == symbols[i] {
+					continue
+				}
+				typo := append([]byte(nil), symbols...)
+				typo[i] = replacement
+				if generator.Validate(ctx, string(typo)) == nil {
+					t.Fatalf("Validate(%s) accepted a substitution of %s", typo, id)
+				}
+			}
+		}
+
+		for i := 0; i+1 < len(symbols); i++ {
+			if symbols[i] == symbols[i+1] {
+				continue
+			}
+			typo := append([]byte(nil), symbols...)
+			typo[i], typo[i+1] = typo[i+1], typo[i]
+			if generator.Validate(ctx, string(typo)) == nil {
+				t.Fatalf("Validate(%s) accepted a transposition of %s", typo, id)
+			}
+		}
+	}
+}
+
+func TestCrockfordGenerator_ValidateRejectsMalformed(t *testing.T) {
+	generator := NewCrockfordGenerator()
+	ctx := context.Background()
+
+	for _, input := range []string{"", "  ", "ABC-DEF", "ABC-DEF-GHJK", "ABC-DEF-GHU", "ABC-DEF-GH!"} {
+		if err := generator.Validate(ctx, input); err == nil {
+			t.Errorf("Validate(%q) error = nil, want error", input)
+		}
+	}
+}
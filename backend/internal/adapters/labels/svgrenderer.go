@@ -0,0 +1,138 @@
+package labels
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.LabelSheetRenderer = (*SVGRenderer)(nil)
+
+const (
+	padding = 2.0
+	// glyphWidth approximates the advance of an average glyph, Cyrillic
+	// included, as a fraction of the font size.
+	glyphWidth  = 0.6
+	maxNameSize = 6.0
+	minNameSize = 3.0
+	idSize      = 3.2
+	fontFamily  = "DejaVu Sans, Arial, sans-serif"
+	monoFamily  = "DejaVu Sans Mono, Consolas, monospace"
+)
+
+// SVGRenderer draws each page as an SVG document sized in millimetres, so
+// printing at 100% scale lines up with the physical label sheet.
+type SVGRenderer struct{}
+
+func NewSVGRenderer() *SVGRenderer {
+	return &SVGRenderer{}
+}
+
+func (r *SVGRenderer) RenderLabelSheet(ctx context.Context, sheet domain.LabelSheet) ([][]byte, error) {
+	preset := sheet.Preset
+	capacity := preset.Capacity()
+	if capacity == 0 {
+		return nil, fmt.Errorf("label preset %q has no slots: %w", preset.Name, domain.ErrInvalid)
+	}
+
+	pages := make([][]byte, 0, sheet.PageCount())
+	var page *bytes.Buffer
+	for i, label := range sheet.Labels {
+		slot := (sheet.Skip + i) % capacity
+		if page == nil || slot == 0 {
+			if page != nil {
+				pages = append(pages, closePage(page))
+			}
+			page = openPage(preset)
+		}
+
+		x, y := preset.Position(slot)
+		writeLabel(page, preset, label, x, y)
+	}
+	if page != nil {
+		pages = append(pages, closePage(page))
+	}
+	return pages, nil
+}
+
+func openPage(preset domain.LabelPreset) *bytes.Buffer {
+	var page bytes.Buffer
+	fmt.Fprintf(
+		&page,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s">`,
+		mm(preset.PageWidth), mm(preset.PageHeight), mm(preset.PageWidth), mm(preset.PageHeight),
+	)
+	return &page
+}
+
+func closePage(page *bytes.Buffer) []byte {
+	page.WriteString(`</svg>`)
+	return page.Bytes()
+}
+
+// writeLabel puts the QR code on the left, filling the label height, and
+// the name above the short ID on the right.
+func writeLabel(page *bytes.Buffer, preset domain.LabelPreset, label domain.Label, x float64, y float64) {
+	qrSize := math.Min(preset.LabelHeight, preset.LabelWidth/2) - 2*padding
+	fmt.Fprintf(
+		page,
+		`<image x="%s" y="%s" width="%s" height="%s" href="data:image/png;base64,%s"/>`,
+		mm(x+padding), mm(y+padding), mm(qrSize), mm(qrSize),
+		base64.StdEncoding.EncodeToString(label.QRCode),
+	)
+
+	textX := x + qrSize + 2*padding
+	textWidth := preset.LabelWidth - qrSize - 3*padding
+	name, nameSize := fitText(label.Name, textWidth)
+	centre := y + preset.LabelHeight/2
+
+	fmt.Fprintf(
+		page,
+		`<text x="%s" y="%s" font-family="%s" font-size="%s" font-weight="bold">%s</text>`,
+		mm(textX), mm(centre-1), fontFamily, mm(nameSize), escape(name),
+	)
+	fmt.Fprintf(
+		page,
+		`<text x="%s" y="%s" font-family="%s" font-size="%s" letter-spacing="0.3">%s</text>`,
+		mm(textX), mm(centre+idSize+1), monoFamily, mm(idSize), escape(label.BrewID),
+	)
+}
+
+// fitText shrinks the font down to minNameSize and then truncates, so long
+// names never spill onto the neighbouring label.
+func fitText(text string, width float64) (string, float64) {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return "", maxNameSize
+	}
+
+	size := math.Min(maxNameSize, width/(float64(len(runes))*glyphWidth))
+	if size >= minNameSize {
+		return text, size
+	}
+
+	fits := int(width / (minNameSize * glyphWidth))
+	if fits < 1 {
+		fits = 1
+	}
+	if fits < len(runes) {
+		runes = append(runes[:fits-1], '…')
+	}
+	return string(runes), minNameSize
+}
+
+func mm(v float64) string {
+	return fmt.Sprintf("%.2f", math.Round(v*100)/100)
+}
+
+func escape(text string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package labels
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"brew/internal/core/domain"
+)
+
+func TestSVGRenderer_Pages(t *testing.T) {
+	preset, err := domain.LabelPresetByName("avery-l7165")
+	if err != nil {
+		t.Fatalf("LabelPresetByName() error = %v", err)
+	}
+
+	sheet := domain.LabelSheet{Preset: preset, Skip: 6}
+	for _, name := range []string{"Скубі", "Big Bertha", "Tom & Jerry"} {
+		sheet.Labels = append(sheet.Labels, domain.Label{BrewID: "7KQ-2M9-XD4", Name: name, QRCode: []byte("png")})
+	}
+
+	pages, err := NewSVGRenderer().RenderLabelSheet(context.Background(), sheet)
+	if err != nil {
+		t.Fatalf("RenderLabelSheet() error = %v", err)
+	}
+	if len(pages) != 2 || sheet.PageCount() != 2 {
+		t.Fatalf("RenderLabelSheet() = %d pages, PageCount() = %d, want 2", len(pages), sheet.PageCount())
+	}
+
+	for i, page := range pages {
+		if err := xml.Unmarshal(page, new(struct{})); err != nil {
+			t.Fatalf("page %d is not well-formed XML: %v", i+1, err)
+		}
+		if !strings.Contains(string(page), `width="210.00mm" height="297.00mm"`) {
+			t.Fatalf("page %d is not A4: %s", i+1, page)
+		}
+	}
+	if got := strings.Count(string(pages[0]), "<image "); got != 2 {
+		t.Fatalf("page 1 has %d labels, want 2", got)
+	}
+	if !strings.Contains(string(pages[0]), ">Скубі</text>") {
+		t.Fatalf("page 1 misses the Cyrillic name: %s", pages[0])
+	}
+	if !strings.Contains(string(pages[1]), ">Tom &amp; Jerry</text>") {
+		t.Fatalf("page 2 misses the escaped name: %s", pages[1])
+	}
+	// The first label of the second page goes into the top-left slot.
+	if !strings.Contains(string(pages[1]), `<image x="6.65" y="15.10"`) {
+		t.Fatalf("page 2 does not start in the first slot: %s", pages[1])
+	}
+}
+
+func TestFitText(t *testing.T) {
+	name, size := fitText("Скубі", 30)
+	if name != "Скубі" || size != maxNameSize {
+		t.Fatalf("fitText(short) = %q, %v", name, size)
+	}
+
+	long := strings.Repeat("Комбуча ", 10)
+	name, size = fitText(long, 30)
+	if size != minNameSize || !strings.HasSuffix(name, "…") || len([]rune(name)) >= len([]rune(long)) {
+		t.Fatalf("fitText(long) = %q, %v", name, size)
+	}
+	if width := float64(len([]rune(name))) * size * glyphWidth; width > 30 {
+		t.Fatalf("fitText(long) is %v mm wide, want at most 30", width)
+	}
+}
@@ -0,0 +1,137 @@
+package qr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/url"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	zxingqr "github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/skip2/go-qrcode"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.QRCodeGenerator = (*Generator)(nil)
+
+const (
+	defaultImageSize = 256
+	jarPathSegment   = "jars"
+	// maxImageSide bounds the images ParseQRCode decodes. A label photo
+	// needs far less, while a small compressed file may declare dimensions
+	// that take gigabytes to decode.
+	maxImageSide = 4096
+)
+
+// Generator encodes jar deep links (<public URL>/jars/<brew ID>) so that a
+// phone camera opens the jar directly, and decodes them back from photos
+// of printed labels.
+type Generator struct {
+	publicURL string
+	size      int
+}
+
+func NewGenerator(publicURL string) *Generator {
+	return &Generator{
+		publicURL: strings.TrimRight(publicURL, "/"),
+		size:      defaultImageSize,
+	}
+}
+
+func (g *Generator) GenerateQRCode(ctx context.Context, brewID string, format ports.QRFormat) ([]byte, error) {
+	code, err := qrcode.New(g.DeepLink(brewID), qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("encode qr code for brew %s: %w", brewID, err)
+	}
+
+	switch format {
+	case ports.QRFormatPNG:
+		return code.PNG(g.size)
+	case ports.QRFormatSVG:
+		return renderSVG(code.Bitmap(), g.size), nil
+	default:
+		return nil, fmt.Errorf("qr format %q: %w", format, domain.ErrInvalid)
+	}
+}
+
+func (g *Generator) DeepLink(brewID string) string {
+	return g.publicURL + "/" + jarPathSegment + "/" + url.PathEscape(brewID)
+}
+
+func (g *Generator) ParseQRCode(ctx context.Context, qrData []byte) (string, error) {
+	payload := string(qrData)
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(qrData))
+	switch {
+	case err == nil:
+		if imageConfig.Width > maxImageSide || imageConfig.Height > maxImageSide {
+			return "", fmt.Errorf(
+				"qr image is %dx%d, larger than %dx%d: %w",
+				imageConfig.Width, imageConfig.Height, maxImageSide, maxImageSide, domain.ErrInvalid,
+			)
+		}
+		img, _, err := image.Decode(bytes.NewReader(qrData))
+		if err != nil {
+			return "", fmt.Errorf("read qr image: %v: %w", err, domain.ErrInvalid)
+		}
+		payload, err = decodeImage(img)
+		if err != nil {
+			return "", err
+		}
+	case !errors.Is(err, image.ErrFormat):
+		return "", fmt.Errorf("read qr image: %v: %w", err, domain.ErrInvalid)
+	}
+
+	return brewIDFromPayload(payload)
+}
+
+func decodeImage(img image.Image) (string, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", fmt.Errorf("read qr image: %v: %w", err, domain.ErrInvalid)
+	}
+
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+	result, err := zxingqr.NewQRCodeReader().Decode(bitmap, hints)
+	if err != nil {
+		return "", fmt.Errorf("no qr code found in image: %w", domain.ErrInvalid)
+	}
+	return result.GetText(), nil
+}
+
+// brewIDFromPayload accepts jar deep links from any host, so labels keep
+// working when the app moves, as well as bare IDs from older labels.
+func brewIDFromPayload(payload string) (string, error) {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return "", fmt.Errorf("qr payload is empty: %w", domain.ErrInvalid)
+	}
+
+	link, err := url.Parse(payload)
+	if err != nil || link.Scheme == "" {
+		return payload, nil
+	}
+
+	segments := strings.Split(strings.Trim(link.EscapedPath(), "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if segments[i] != jarPathSegment {
+			continue
+		}
+		brewID, err := url.PathUnescape(segments[i+1])
+		if err != nil || brewID == "" {
+			break
+		}
+		return brewID, nil
+	}
+	return "", fmt.Errorf("qr code links to %s, not to a jar: %w", payload, domain.ErrInvalid)
+}
@@ -0,0 +1,136 @@
+package qr
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestGenerator_PNGRoundTrip(t *testing.T) {
+	generator := NewGenerator("https://brew.example/")
+	ctx := context.Background()
+
+	png, err := generator.GenerateQRCode(ctx, "abc-123", ports.QRFormatPNG)
+	if err != nil {
+		t.Fatalf("GenerateQRCode() error = %v", err)
+	}
+
+	brewID, err := generator.ParseQRCode(ctx, png)
+	if err != nil {
+		t.Fatalf("ParseQRCode() error = %v", err)
+	}
+	if brewID != "abc-123" {
+		t.Fatalf("ParseQRCode() = %s, want abc-123", brewID)
+	}
+}
+
+func TestGenerator_ParsesPhotoOfLabel(t *testing.T) {
+	code, err := qrcode.New("https://brew.example/jars/photo-1", qrcode.Medium)
+	if err != nil {
+		t.Fatalf("qrcode.New() error = %v", err)
+	}
+
+	// A lossy grey-on-beige image is closer to a phone photo than a clean PNG.
+	label := code.Image(320)
+	photo := image.NewRGBA(label.Bounds())
+	for y := 0; y < label.Bounds().Dy(); y++ {
+		for x := 0; x < label.Bounds().Dx(); x++ {
+			r, _, _, _ := label.At(x, y).RGBA()
+			if r == 0 {
+				photo.Set(x, y, color.RGBA{R: 40, G: 40, B: 50, A: 255})
+			} else {
+				photo.Set(x, y, color.RGBA{R: 230, G: 220, B: 200, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, photo, &jpeg.Options{Quality: 60}); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	brewID, err := NewGenerator("https://brew.example").ParseQRCode(context.Background(), buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseQRCode() error = %v", err)
+	}
+	if brewID != "photo-1" {
+		t.Fatalf("ParseQRCode() = %s, want photo-1", brewID)
+	}
+}
+
+func TestGenerator_RejectsHugeImage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	// Claim 20000x20000 in the IHDR chunk, which directly follows the 8-byte
+	// signature, and fix up its CRC: a few bytes that would decode to 400 MB.
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[16:], 20000)
+	binary.BigEndian.PutUint32(data[20:], 20000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := NewGenerator("https://brew.example").ParseQRCode(context.Background(), data)
+	if !errors.Is(err, domain.ErrInvalid) || !strings.Contains(err.Error(), "20000x20000") {
+		t.Fatalf("ParseQRCode() error = %v, want ErrInvalid for the image size", err)
+	}
+}
+
+func TestGenerator_SVG(t *testing.T) {
+	svg, err := NewGenerator("https://brew.example").GenerateQRCode(context.Background(), "abc-123", ports.QRFormatSVG)
+	if err != nil {
+		t.Fatalf("GenerateQRCode() error = %v", err)
+	}
+
+	got := string(svg)
+	if !strings.HasPrefix(got, "<svg ") || !strings.HasSuffix(got, "</svg>") || !strings.Contains(got, `<path fill="#000" d="M`) {
+		t.Fatalf("GenerateQRCode() svg = %s", got)
+	}
+}
+
+func TestGenerator_UnknownFormat(t *testing.T) {
+	_, err := NewGenerator("https://brew.example").GenerateQRCode(context.Background(), "abc-123", "gif")
+	if !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("GenerateQRCode() error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestGenerator_ParseTextPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+		wantErr bool
+	}{
+		{name: "deep link", payload: "https://brew.example/jars/abc-123", want: "abc-123"},
+		{name: "deep link from another host", payload: "http://localhost:8080/app/jars/abc-123?ref=label", want: "abc-123"},
+		{name: "escaped id", payload: "https://brew.example/jars/%D0%A1%D0%BA%D1%83%D0%B1%D1%96", want: "Скубі"},
+		{name: "bare id from an old label", payload: " abc-123\n", want: "abc-123"},
+		{name: "foreign link", payload: "https://example.com/menu", wantErr: true},
+		{name: "empty", payload: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGenerator("https://brew.example").ParseQRCode(context.Background(), []byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQRCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseQRCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
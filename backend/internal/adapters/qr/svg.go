@@ -0,0 +1,38 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// renderSVG draws the module bitmap, quiet zone included, as a single path
+// of horizontal runs so the label stays sharp at any print size.
+func renderSVG(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+
+	var path bytes.Buffer
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	var svg bytes.Buffer
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, modules, modules,
+	)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/>`, modules, modules)
+	fmt.Fprintf(&svg, `<path fill="#000" d="%s"/>`, path.String())
+	svg.WriteString(`</svg>`)
+	return svg.Bytes()
+}
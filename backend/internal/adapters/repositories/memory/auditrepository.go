@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.AuditRepository = (*AuditRepository)(nil)
+
+type AuditRepository struct {
+	mu      sync.RWMutex
+	entries map[string]*domain.AuditEntry
+	lastSeq int64
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		entries: make(map[string]*domain.AuditEntry),
+	}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[entry.ID]; ok {
+		return fmt.Errorf("audit entry %s: %w", entry.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.entries, entry.ID))
+	r.lastSeq++
+	entry.Seq = r.lastSeq
+	r.entries[entry.ID] = cloneAuditEntry(entry)
+	return nil
+}
+
+func (r *AuditRepository) GetByBrewID(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	return r.list(pointer, limit, func(entry *domain.AuditEntry) bool {
+		return entry.BrewID == brewID
+	})
+}
+
+func (r *AuditRepository) GetBySessionID(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	return r.list(pointer, limit, func(entry *domain.AuditEntry) bool {
+		return entry.SessionID == sessionID
+	})
+}
+
+func (r *AuditRepository) GetSince(
+	ctx context.Context,
+	sessionID string,
+	afterSeq int64,
+	limit int,
+) ([]*domain.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []*domain.AuditEntry{}
+	for _, entry := range r.entries {
+		if entry.SessionID == sessionID && entry.Seq > afterSeq {
+			entries = append(entries, cloneAuditEntry(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Seq < entries[j].Seq
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (r *AuditRepository) list(
+	pointer *string,
+	limit int,
+	matches func(entry *domain.AuditEntry) bool,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []*domain.AuditEntry
+	for _, entry := range r.entries {
+		if matches(entry) {
+			items = append(items, entry)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return auditEntryBefore(items[i], items[j])
+	})
+
+	total := len(items)
+	if pointer != nil {
+		last, ok := r.entries[*pointer]
+		if !ok || !matches(last) {
+			return nil, fmt.Errorf("pointer %s: %w", *pointer, domain.ErrNotFound)
+		}
+		start := sort.Search(len(items), func(i int) bool {
+			return auditEntryBefore(last, items[i])
+		})
+		items = items[start:]
+	}
+
+	return paginate(items, total, limit, func(entry *domain.AuditEntry) string { return entry.ID }, cloneAuditEntry), nil
+}
+
+func auditEntryBefore(a, b *domain.AuditEntry) bool {
+	if !a.At.Equal(b.At) {
+		return a.At.Before(b.At)
+	}
+	return a.ID < b.ID
+}
+
+func cloneAuditEntry(entry *domain.AuditEntry) *domain.AuditEntry {
+	clone := *entry
+	return &clone
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.BrewRecordRepository = (*BrewRecordRepository)(nil)
+
+type BrewRecordRepository struct {
+	mu      sync.RWMutex
+	records map[string]*domain.BrewRecord
+	notes   map[string][]*domain.RecordNote
+}
+
+func NewBrewRecordRepository() *BrewRecordRepository {
+	return &BrewRecordRepository{
+		records: make(map[string]*domain.BrewRecord),
+		notes:   make(map[string][]*domain.RecordNote),
+	}
+}
+
+func (r *BrewRecordRepository) Save(ctx context.Context, record *domain.BrewRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[record.ID]; ok {
+		return fmt.Errorf("brew record %s: %w", record.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.records, record.ID))
+	r.records[record.ID] = cloneBrewRecord(record)
+	return nil
+}
+
+func (r *BrewRecordRepository) GetByID(ctx context.Context, id string) (*domain.BrewRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return nil, fmt.Errorf("brew record %s: %w", id, domain.ErrNotFound)
+	}
+	return cloneBrewRecord(record), nil
+}
+
+func (r *BrewRecordRepository) GetByBrewID(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.BrewRecord], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []*domain.BrewRecord
+	for _, record := range r.records {
+		if record.BrewID == brewID {
+			items = append(items, record)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return brewRecordBefore(items[i], items[j])
+	})
+
+	total := len(items)
+	if pointer != nil {
+		last, ok := r.records[*pointer]
+		if !ok || last.BrewID != brewID {
+			return nil, fmt.Errorf("pointer %s: %w", *pointer, domain.ErrNotFound)
+		}
+		start := sort.Search(len(items), func(i int) bool {
+			return brewRecordBefore(last, items[i])
+		})
+		items = items[start:]
+	}
+
+	return paginate(items, total, limit, func(record *domain.BrewRecord) string { return record.ID }, cloneBrewRecord), nil
+}
+
+func (r *BrewRecordRepository) Update(ctx context.Context, record *domain.BrewRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.records[record.ID]
+	if !ok {
+		return fmt.Errorf("brew record %s: %w", record.ID, domain.ErrNotFound)
+	}
+	if stored.IsSubmitted() {
+		return fmt.Errorf("brew record %s: %w", record.ID, domain.ErrImmutable)
+	}
+	onRollback(ctx, restore(&r.mu, r.records, record.ID))
+	r.records[record.ID] = cloneBrewRecord(record)
+	return nil
+}
+
+func (r *BrewRecordRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.records[id]
+	if !ok {
+		return fmt.Errorf("brew record %s: %w", id, domain.ErrNotFound)
+	}
+	if stored.IsSubmitted() {
+		return fmt.Errorf("brew record %s: %w", id, domain.ErrImmutable)
+	}
+	onRollback(ctx, restore(&r.mu, r.records, id))
+	onRollback(ctx, restore(&r.mu, r.notes, id))
+	delete(r.records, id)
+	delete(r.notes, id)
+	return nil
+}
+
+func (r *BrewRecordRepository) AppendNote(ctx context.Context, note *domain.RecordNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[note.RecordID]; !ok {
+		return fmt.Errorf("brew record %s: %w", note.RecordID, domain.ErrNotFound)
+	}
+	for _, existing := range r.notes[note.RecordID] {
+		if existing.ID == note.ID {
+			return fmt.Errorf("record note %s: %w", note.ID, domain.ErrAlreadyExists)
+		}
+	}
+
+	onRollback(ctx, restore(&r.mu, r.notes, note.RecordID))
+	clone := *note
+	r.notes[note.RecordID] = append(r.notes[note.RecordID], &clone)
+	return nil
+}
+
+func (r *BrewRecordRepository) GetNotes(ctx context.Context, recordID string) ([]*domain.RecordNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.records[recordID]; !ok {
+		return nil, fmt.Errorf("brew record %s: %w", recordID, domain.ErrNotFound)
+	}
+
+	notes := make([]*domain.RecordNote, 0, len(r.notes[recordID]))
+	for _, note := range r.notes[recordID] {
+		clone := *note
+		notes = append(notes, &clone)
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+func brewRecordBefore(a, b *domain.BrewRecord) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+func cloneBrewRecord(record *domain.BrewRecord) *domain.BrewRecord {
+	clone := *record
+	clone.SubmittedAt = cloneTime(record.SubmittedAt)
+	if record.Recipe.Extras != nil {
+		clone.Recipe.Extras = append([]domain.Ingredient(nil), record.Recipe.Extras...)
+	}
+	return &clone
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.BrewRepository = (*BrewRepository)(nil)
+
+type BrewRepository struct {
+	mu    sync.RWMutex
+	brews map[string]*domain.Brew
+}
+
+func NewBrewRepository() *BrewRepository {
+	return &BrewRepository{
+		brews: make(map[string]*domain.Brew),
+	}
+}
+
+func (r *BrewRepository) Save(ctx context.Context, brew *domain.Brew) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.brews[brew.ID]; ok {
+		return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.brews, brew.ID))
+	r.brews[brew.ID] = cloneBrew(brew)
+	return nil
+}
+
+func (r *BrewRepository) GetByID(ctx context.Context, id string) (*domain.Brew, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	brew, ok := r.brews[id]
+	if !ok {
+		return nil, fmt.Errorf("brew %s: %w", id, domain.ErrNotFound)
+	}
+	return cloneBrew(brew), nil
+}
+
+func (r *BrewRepository) GetBySessionID(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.Brew], error) {
+	return r.Query(ctx, domain.BrewQuery{
+		SessionID: sessionID,
+		Sort:      domain.SortByCreated,
+		Pointer:   pointer,
+		Limit:     limit,
+	})
+}
+
+func (r *BrewRepository) Query(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []*domain.Brew
+	for _, brew := range r.brews {
+		if brew.SessionID == query.SessionID && query.Status.Matches(brew) && domain.MatchesSearch(brew.Name, query.Search) {
+			items = append(items, brew)
+		}
+	}
+	before := brewOrder(query.Sort, query.Descending)
+	sort.Slice(items, func(i, j int) bool {
+		return before(items[i], items[j])
+	})
+
+	total := len(items)
+	if query.Pointer != nil {
+		last, ok := r.brews[*query.Pointer]
+		if !ok || last.SessionID != query.SessionID {
+			return nil, fmt.Errorf("pointer %s: %w", *query.Pointer, domain.ErrNotFound)
+		}
+		start := sort.Search(len(items), func(i int) bool {
+			return before(last, items[i])
+		})
+		items = items[start:]
+	}
+
+	return paginate(items, total, query.Limit, func(brew *domain.Brew) string { return brew.ID }, cloneBrew), nil
+}
+
+func (r *BrewRepository) Update(ctx context.Context, brew *domain.Brew) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.brews[brew.ID]
+	if !ok {
+		return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrNotFound)
+	}
+	if stored.Version != brew.Version {
+		return fmt.Errorf("brew %s is at version %d, not %d: %w", brew.ID, stored.Version, brew.Version, domain.ErrConflict)
+	}
+	onRollback(ctx, restore(&r.mu, r.brews, brew.ID))
+	brew.Version++
+	updated := cloneBrew(brew)
+	updated.NextActionAt = stored.NextActionAt
+	r.brews[brew.ID] = updated
+	return nil
+}
+
+func (r *BrewRepository) SetNextAction(ctx context.Context, id string, at *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.brews[id]
+	if !ok {
+		return fmt.Errorf("brew %s: %w", id, domain.ErrNotFound)
+	}
+	onRollback(ctx, restore(&r.mu, r.brews, id))
+	updated := cloneBrew(stored)
+	updated.NextActionAt = cloneTime(at)
+	r.brews[id] = updated
+	return nil
+}
+
+func (r *BrewRepository) Exists(ctx context.Context, id string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.brews[id]
+	return ok, nil
+}
+
+// brewOrder orders like the SQLite adapter: by the sort key at microsecond
+// precision, then by ID, both in the requested direction.
+func brewOrder(by domain.BrewSort, descending bool) func(a, b *domain.Brew) bool {
+	return func(a, b *domain.Brew) bool {
+		keyA, keyB := brewSortKey(a, by, descending), brewSortKey(b, by, descending)
+		if descending {
+			return keyA > keyB || keyA == keyB && a.ID > b.ID
+		}
+		return keyA < keyB || keyA == keyB && a.ID < b.ID
+	}
+}
+
+func brewSortKey(brew *domain.Brew, by domain.BrewSort, descending bool) int64 {
+	switch by {
+	case domain.SortByUpdated:
+		return brew.UpdatedAt.UnixMicro()
+	case domain.SortByNextAction:
+		if brew.NextActionAt == nil {
+			// Nothing planned goes last in either direction.
+			if descending {
+				return math.MinInt64
+			}
+			return math.MaxInt64
+		}
+		return brew.NextActionAt.UnixMicro()
+	default:
+		return brew.CreatedAt.UnixMicro()
+	}
+}
+
+func cloneBrew(brew *domain.Brew) *domain.Brew {
+	clone := *brew
+	clone.ArchivedAt = cloneTime(brew.ArchivedAt)
+	clone.NextActionAt = cloneTime(brew.NextActionAt)
+	return &clone
+}
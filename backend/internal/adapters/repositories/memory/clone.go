@@ -0,0 +1,11 @@
+package memory
+
+import "time"
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
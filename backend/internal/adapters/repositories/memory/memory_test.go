@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"brew/internal/adapters/repositories/repositorytest"
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestBrewRepository_Contract(t *testing.T) {
+	repositorytest.TestBrewRepository(t, func(t *testing.T) ports.BrewRepository {
+		return NewBrewRepository()
+	})
+}
+
+func TestBrewRecordRepository_Contract(t *testing.T) {
+	repositorytest.TestBrewRecordRepository(t, func(t *testing.T) ports.BrewRecordRepository {
+		return NewBrewRecordRepository()
+	})
+}
+
+func TestTimelineRepository_Contract(t *testing.T) {
+	repositorytest.TestTimelineRepository(t, func(t *testing.T) ports.TimelineRepository {
+		return NewTimelineRepository()
+	})
+}
+
+func TestQualityRepository_Contract(t *testing.T) {
+	repositorytest.TestQualityRepository(t, func(t *testing.T) ports.QualityRepository {
+		return NewQualityRepository()
+	})
+}
+
+func TestAuditRepository_Contract(t *testing.T) {
+	repositorytest.TestAuditRepository(t, func(t *testing.T) ports.AuditRepository {
+		return NewAuditRepository()
+	})
+}
+
+func TestTransferRepository_Contract(t *testing.T) {
+	repositorytest.TestTransferRepository(t, func(t *testing.T) repositorytest.TransferRepositories {
+		brews := NewBrewRepository()
+		records := NewBrewRecordRepository()
+		return repositorytest.TransferRepositories{
+			Transfers: NewTransferRepository(brews, records),
+			Brews:     brews,
+			Records:   records,
+		}
+	})
+}
+
+func TestSyncRepository_Contract(t *testing.T) {
+	repositorytest.TestSyncRepository(t, func(t *testing.T) ports.SyncRepository {
+		return NewSyncRepository()
+	})
+}
+
+func TestSearchRepository_Contract(t *testing.T) {
+	repositorytest.TestSearchRepository(t, func(t *testing.T) ports.SearchRepository {
+		return NewSearchRepository()
+	})
+}
+
+func TestSessionRepository_Contract(t *testing.T) {
+	repositorytest.TestSessionRepository(t, func(t *testing.T) ports.SessionRepository {
+		return NewSessionRepository()
+	})
+}
+
+func TestTransactor_Contract(t *testing.T) {
+	repositorytest.TestTransactor(t, func(t *testing.T) repositorytest.TransactorRepositories {
+		return repositorytest.TransactorRepositories{
+			Transactor: NewTransactor(),
+			Brews:      NewBrewRepository(),
+			Audit:      NewAuditRepository(),
+			Syncs:      NewSyncRepository(),
+		}
+	})
+}
+
+func TestBrewRepository_ConcurrentAccess(t *testing.T) {
+	repo := NewBrewRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			brew := &domain.Brew{ID: fmt.Sprintf("brew-%d", i), SessionID: "session-1"}
+			if err := repo.Save(ctx, brew); err != nil {
+				t.Errorf("Save() error = %v", err)
+			}
+			repo.GetBySessionID(ctx, "session-1", nil, 10)
+			repo.Exists(ctx, brew.ID)
+		}(i)
+	}
+	wg.Wait()
+
+	page, err := repo.GetBySessionID(ctx, "session-1", nil, 0)
+	if err != nil {
+		t.Fatalf("GetBySessionID() error = %v", err)
+	}
+	if page.TotalCount != 50 {
+		t.Fatalf("TotalCount = %d, want 50", page.TotalCount)
+	}
+}
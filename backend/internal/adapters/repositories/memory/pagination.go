@@ -0,0 +1,27 @@
+package memory
+
+import "brew/internal/core/ports"
+
+// paginate cuts a page of at most limit items from an already ordered slice,
+// cloning each item so callers never share memory with the repository.
+func paginate[T any](
+	items []T,
+	total int,
+	limit int,
+	pointerOf func(T) string,
+	clone func(T) T,
+) *ports.PaginatedResult[T] {
+	result := &ports.PaginatedResult[T]{TotalCount: total}
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+		next := pointerOf(items[len(items)-1])
+		result.NextPointer = &next
+		result.HasMore = true
+	}
+
+	result.Items = make([]T, 0, len(items))
+	for _, item := range items {
+		result.Items = append(result.Items, clone(item))
+	}
+	return result
+}
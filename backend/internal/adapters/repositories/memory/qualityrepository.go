@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.QualityRepository = (*QualityRepository)(nil)
+
+type QualityRepository struct {
+	mu          sync.RWMutex
+	evaluations map[string]*domain.QualityEvaluation
+}
+
+func NewQualityRepository() *QualityRepository {
+	return &QualityRepository{
+		evaluations: make(map[string]*domain.QualityEvaluation),
+	}
+}
+
+func (r *QualityRepository) Save(ctx context.Context, evaluation *domain.QualityEvaluation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.evaluations[evaluation.ID]; ok {
+		return fmt.Errorf("quality evaluation %s: %w", evaluation.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.evaluations, evaluation.ID))
+	r.evaluations[evaluation.ID] = cloneQualityEvaluation(evaluation)
+	return nil
+}
+
+func (r *QualityRepository) GetByID(ctx context.Context, id string) (*domain.QualityEvaluation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	evaluation, ok := r.evaluations[id]
+	if !ok {
+		return nil, fmt.Errorf("quality evaluation %s: %w", id, domain.ErrNotFound)
+	}
+	return cloneQualityEvaluation(evaluation), nil
+}
+
+func (r *QualityRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	evaluations := []*domain.QualityEvaluation{}
+	for _, evaluation := range r.evaluations {
+		if evaluation.BrewID == brewID {
+			evaluations = append(evaluations, cloneQualityEvaluation(evaluation))
+		}
+	}
+	sort.Slice(evaluations, func(i, j int) bool {
+		if !evaluations[i].CreatedAt.Equal(evaluations[j].CreatedAt) {
+			return evaluations[i].CreatedAt.Before(evaluations[j].CreatedAt)
+		}
+		return evaluations[i].ID < evaluations[j].ID
+	})
+	return evaluations, nil
+}
+
+func cloneQualityEvaluation(evaluation *domain.QualityEvaluation) *domain.QualityEvaluation {
+	clone := *evaluation
+	if evaluation.Score.PH != nil {
+		ph := *evaluation.Score.PH
+		clone.Score.PH = &ph
+	}
+	return &clone
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SearchRepository = (*SearchRepository)(nil)
+
+// BM25 parameters as SQLite's FTS5 uses them, so both adapters rank alike.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type searchKey struct {
+	kind domain.SearchKind
+	id   string
+}
+
+type searchEntry struct {
+	document domain.SearchDocument
+	words    []string
+}
+
+type SearchRepository struct {
+	mu      sync.RWMutex
+	entries map[searchKey]*searchEntry
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{
+		entries: make(map[searchKey]*searchEntry),
+	}
+}
+
+func (r *SearchRepository) Index(ctx context.Context, document *domain.SearchDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := searchKey{kind: document.Kind, id: document.ID}
+	onRollback(ctx, restore(&r.mu, r.entries, key))
+	r.entries[key] = &searchEntry{
+		document: *document,
+		words:    domain.SearchTerms(document.Text),
+	}
+	return nil
+}
+
+func (r *SearchRepository) MoveBrew(ctx context.Context, brewID string, sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, entry := range r.entries {
+		if entry.document.BrewID == brewID {
+			onRollback(ctx, restore(&r.mu, r.entries, key))
+			moved := *entry
+			moved.document.SessionID = sessionID
+			r.entries[key] = &moved
+		}
+	}
+	return nil
+}
+
+func (r *SearchRepository) Search(
+	ctx context.Context,
+	sessionID string,
+	query string,
+	limit int,
+) ([]*domain.SearchHit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	// Like FTS5, document frequencies and the average length are taken
+	// over the whole index rather than the session.
+	matching := make([]int, len(terms))
+	totalWords := 0
+	for _, entry := range r.entries {
+		totalWords += len(entry.words)
+		for i, term := range terms {
+			if countPrefixed(entry.words, term) > 0 {
+				matching[i]++
+			}
+		}
+	}
+	count := float64(len(r.entries))
+	averageWords := float64(totalWords) / count
+
+	var hits []*domain.SearchHit
+	for _, entry := range r.entries {
+		if entry.document.SessionID != sessionID {
+			continue
+		}
+		score := 0.0
+		for i, term := range terms {
+			frequency := float64(countPrefixed(entry.words, term))
+			if frequency == 0 {
+				score = 0
+				break
+			}
+			idf := math.Log((count - float64(matching[i]) + 0.5) / (float64(matching[i]) + 0.5))
+			if idf <= 0 {
+				idf = 1e-6
+			}
+			length := float64(len(entry.words))
+			score += idf * frequency * (bm25K1 + 1) / (frequency + bm25K1*(1-bm25B+bm25B*length/averageWords))
+		}
+		if score > 0 {
+			hits = append(hits, &domain.SearchHit{Document: entry.document, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		return searchHitBefore(hits[i], hits[j])
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func countPrefixed(words []string, term string) int {
+	count := 0
+	for _, word := range words {
+		if strings.HasPrefix(word, term) {
+			count++
+		}
+	}
+	return count
+}
+
+// searchHitBefore orders like the SQLite adapter: best score first, then
+// the newest document, then by kind and ID.
+func searchHitBefore(a, b *domain.SearchHit) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if !a.Document.At.Equal(b.Document.At) {
+		return a.Document.At.After(b.Document.At)
+	}
+	if a.Document.Kind != b.Document.Kind {
+		return a.Document.Kind < b.Document.Kind
+	}
+	return a.Document.ID < b.Document.ID
+}
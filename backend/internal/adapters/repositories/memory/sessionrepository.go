@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SessionRepository = (*SessionRepository)(nil)
+
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*domain.Session
+}
+
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: make(map[string]*domain.Session),
+	}
+}
+
+func (r *SessionRepository) Save(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[session.ID]; ok {
+		return fmt.Errorf("session %s: %w", session.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.sessions, session.ID))
+	r.sessions[session.ID] = cloneSession(session)
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+	}
+	return cloneSession(session), nil
+}
+
+func (r *SessionRepository) GetByShareToken(ctx context.Context, token string) (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, session := range r.sessions {
+		if session.FindShareToken(token) != nil {
+			return cloneSession(session), nil
+		}
+	}
+	return nil, fmt.Errorf("share token: %w", domain.ErrNotFound)
+}
+
+func (r *SessionRepository) GetExpired(ctx context.Context, now time.Time, ttl time.Duration) ([]*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := []*domain.Session{}
+	for _, session := range r.sessions {
+		if session.IsExpired(now, ttl) {
+			sessions = append(sessions, cloneSession(session))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions, nil
+}
+
+func (r *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sessions[session.ID]
+	if !ok {
+		return fmt.Errorf("session %s: %w", session.ID, domain.ErrNotFound)
+	}
+	if stored.Version != session.Version {
+		return fmt.Errorf("session %s is at version %d, not %d: %w", session.ID, stored.Version, session.Version, domain.ErrConflict)
+	}
+	onRollback(ctx, restore(&r.mu, r.sessions, session.ID))
+	session.Version++
+	r.sessions[session.ID] = cloneSession(session)
+	return nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+	}
+	onRollback(ctx, restore(&r.mu, r.sessions, id))
+	touched := cloneSession(session)
+	touched.LastAccessed = at
+	r.sessions[id] = touched
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	onRollback(ctx, restore(&r.mu, r.sessions, id))
+	delete(r.sessions, id)
+	return nil
+}
+
+func cloneSession(session *domain.Session) *domain.Session {
+	clone := *session
+	clone.ExpiresAt = cloneTime(session.ExpiresAt)
+	if session.ShareTokens != nil {
+		clone.ShareTokens = make([]domain.ShareToken, len(session.ShareTokens))
+		for i, token := range session.ShareTokens {
+			token.ExpiresAt = cloneTime(token.ExpiresAt)
+			clone.ShareTokens[i] = token
+		}
+	}
+	return &clone
+}
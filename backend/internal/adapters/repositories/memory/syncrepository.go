@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SyncRepository = (*SyncRepository)(nil)
+
+type syncKey struct {
+	sessionID   string
+	operationID string
+}
+
+type SyncRepository struct {
+	mu      sync.Mutex
+	results map[syncKey]*domain.SyncResult
+}
+
+func NewSyncRepository() *SyncRepository {
+	return &SyncRepository{
+		results: make(map[syncKey]*domain.SyncResult),
+	}
+}
+
+func (r *SyncRepository) Claim(
+	ctx context.Context,
+	result *domain.SyncResult,
+	staleBefore time.Time,
+) (*domain.SyncResult, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := syncKey{sessionID: result.SessionID, operationID: result.OperationID}
+	if stored, ok := r.results[key]; ok {
+		if stored.Status != domain.SyncPending || !stored.ReceivedAt.Before(staleBefore) {
+			return cloneSyncResult(stored), false, nil
+		}
+	}
+	onRollback(ctx, restore(&r.mu, r.results, key))
+	r.results[key] = cloneSyncResult(result)
+	return cloneSyncResult(result), true, nil
+}
+
+func (r *SyncRepository) Complete(ctx context.Context, result *domain.SyncResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := syncKey{sessionID: result.SessionID, operationID: result.OperationID}
+	if _, ok := r.results[key]; !ok {
+		return fmt.Errorf("sync operation %s: %w", result.OperationID, domain.ErrNotFound)
+	}
+	onRollback(ctx, restore(&r.mu, r.results, key))
+	r.results[key] = cloneSyncResult(result)
+	return nil
+}
+
+func (r *SyncRepository) Release(ctx context.Context, sessionID string, operationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := syncKey{sessionID: sessionID, operationID: operationID}
+	if stored, ok := r.results[key]; ok && stored.Status == domain.SyncPending {
+		onRollback(ctx, restore(&r.mu, r.results, key))
+		delete(r.results, key)
+	}
+	return nil
+}
+
+func (r *SyncRepository) Get(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.results[syncKey{sessionID: sessionID, operationID: operationID}]
+	if !ok {
+		return nil, fmt.Errorf("sync operation %s: %w", operationID, domain.ErrNotFound)
+	}
+	return cloneSyncResult(stored), nil
+}
+
+func cloneSyncResult(result *domain.SyncResult) *domain.SyncResult {
+	clone := *result
+	return &clone
+}
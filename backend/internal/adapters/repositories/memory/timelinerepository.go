@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TimelineRepository = (*TimelineRepository)(nil)
+
+type TimelineRepository struct {
+	mu     sync.RWMutex
+	events map[string]*domain.TimelineEvent
+}
+
+func NewTimelineRepository() *TimelineRepository {
+	return &TimelineRepository{
+		events: make(map[string]*domain.TimelineEvent),
+	}
+}
+
+func (r *TimelineRepository) Save(ctx context.Context, event *domain.TimelineEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.events[event.ID]; ok {
+		return fmt.Errorf("timeline event %s: %w", event.ID, domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.events, event.ID))
+	r.events[event.ID] = cloneTimelineEvent(event)
+	return nil
+}
+
+func (r *TimelineRepository) GetByID(ctx context.Context, id string) (*domain.TimelineEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, ok := r.events[id]
+	if !ok {
+		return nil, fmt.Errorf("timeline event %s: %w", id, domain.ErrNotFound)
+	}
+	return cloneTimelineEvent(event), nil
+}
+
+func (r *TimelineRepository) Update(ctx context.Context, event *domain.TimelineEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.events[event.ID]; !ok {
+		return fmt.Errorf("timeline event %s: %w", event.ID, domain.ErrNotFound)
+	}
+	onRollback(ctx, restore(&r.mu, r.events, event.ID))
+	r.events[event.ID] = cloneTimelineEvent(event)
+	return nil
+}
+
+func (r *TimelineRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []*domain.TimelineEvent{}
+	for _, event := range r.events {
+		if event.BrewID == brewID {
+			events = append(events, cloneTimelineEvent(event))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].At.Equal(events[j].At) {
+			return events[i].At.Before(events[j].At)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+func cloneTimelineEvent(event *domain.TimelineEvent) *domain.TimelineEvent {
+	clone := *event
+	clone.CompletedAt = cloneTime(event.CompletedAt)
+	return &clone
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"brew/internal/core/ports"
+)
+
+var _ ports.Transactor = (*Transactor)(nil)
+
+// Transactor undoes the writes of a failed fn from a journal the
+// repositories keep in the context. Transactions run one at a time so that
+// undoing one never overwrites another's writes, but their writes are
+// visible to plain reads before they end.
+type Transactor struct {
+	mu sync.Mutex
+}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	outer, nested := ctx.Value(journalKey{}).(*journal)
+	if !nested {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	current := &journal{}
+	if err := fn(context.WithValue(ctx, journalKey{}, current)); err != nil {
+		current.rollback()
+		return err
+	}
+	if nested {
+		// The outer transaction may still fail and must then undo these too.
+		outer.undos = append(outer.undos, current.undos...)
+	}
+	return nil
+}
+
+type journalKey struct{}
+
+// journal lists how to undo each write of one transaction, in the order
+// the writes were made.
+type journal struct {
+	undos []func()
+}
+
+func (j *journal) rollback() {
+	for i := len(j.undos) - 1; i >= 0; i-- {
+		j.undos[i]()
+	}
+}
+
+// onRollback keeps undo in the journal of ctx's transaction. Outside a
+// transaction a write is final and undo is dropped.
+func onRollback(ctx context.Context, undo func()) {
+	if j, ok := ctx.Value(journalKey{}).(*journal); ok {
+		j.undos = append(j.undos, undo)
+	}
+}
+
+// restore returns an undo that puts m[key] back as it is now, or removes it
+// if it is absent. The caller must hold mu, and writes must replace stored
+// values rather than change them in place.
+func restore[K comparable, V any](mu sync.Locker, m map[K]V, key K) func() {
+	previous, existed := m[key]
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if existed {
+			m[key] = previous
+		} else {
+			delete(m, key)
+		}
+	}
+}
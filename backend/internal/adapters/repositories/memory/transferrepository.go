@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TransferRepository = (*TransferRepository)(nil)
+
+// TransferRepository moves jars between sessions inside the brew and record
+// repositories it is given, holding all their locks while it does so.
+type TransferRepository struct {
+	mu      sync.Mutex
+	tokens  map[string]*domain.TransferToken
+	brews   *BrewRepository
+	records *BrewRecordRepository
+}
+
+func NewTransferRepository(brews *BrewRepository, records *BrewRecordRepository) *TransferRepository {
+	return &TransferRepository{
+		tokens:  make(map[string]*domain.TransferToken),
+		brews:   brews,
+		records: records,
+	}
+}
+
+func (r *TransferRepository) Save(ctx context.Context, token *domain.TransferToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tokens[token.Token]; ok {
+		return fmt.Errorf("transfer token: %w", domain.ErrAlreadyExists)
+	}
+	onRollback(ctx, restore(&r.mu, r.tokens, token.Token))
+	r.tokens[token.Token] = cloneTransferToken(token)
+	return nil
+}
+
+func (r *TransferRepository) GetByToken(ctx context.Context, token string) (*domain.TransferToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[token]
+	if !ok {
+		return nil, fmt.Errorf("transfer token: %w", domain.ErrNotFound)
+	}
+	return cloneTransferToken(stored), nil
+}
+
+func (r *TransferRepository) Redeem(
+	ctx context.Context,
+	token string,
+	toSessionID string,
+	now time.Time,
+) (*domain.TransferToken, error) {
+	// Locks are always taken in this order: transfers, brews, records.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.brews.mu.Lock()
+	defer r.brews.mu.Unlock()
+	r.records.mu.Lock()
+	defer r.records.mu.Unlock()
+
+	stored, ok := r.tokens[token]
+	if !ok {
+		return nil, fmt.Errorf("transfer token: %w", domain.ErrNotFound)
+	}
+	if !stored.IsUsable(now) {
+		return nil, fmt.Errorf("transfer token is used or expired: %w", domain.ErrForbidden)
+	}
+	brew, ok := r.brews.brews[stored.BrewID]
+	if !ok {
+		return nil, fmt.Errorf("brew %s: %w", stored.BrewID, domain.ErrNotFound)
+	}
+	if brew.SessionID != stored.FromSessionID {
+		return nil, fmt.Errorf("brew %s changed owner since the transfer was offered: %w", brew.ID, domain.ErrForbidden)
+	}
+
+	onRollback(ctx, restore(&r.brews.mu, r.brews.brews, brew.ID))
+	moved := cloneBrew(brew)
+	moved.SessionID = toSessionID
+	moved.UpdatedAt = now
+	moved.Version++
+	r.brews.brews[brew.ID] = moved
+	for id, record := range r.records.records {
+		if record.BrewID == brew.ID {
+			onRollback(ctx, restore(&r.records.mu, r.records.records, id))
+			moved := cloneBrewRecord(record)
+			moved.SessionID = toSessionID
+			r.records.records[id] = moved
+		}
+	}
+
+	onRollback(ctx, restore(&r.mu, r.tokens, token))
+	consumed := cloneTransferToken(stored)
+	consumedAt := now
+	consumed.ConsumedAt = &consumedAt
+	consumed.ConsumedBy = toSessionID
+	r.tokens[token] = consumed
+	return cloneTransferToken(consumed), nil
+}
+
+func cloneTransferToken(token *domain.TransferToken) *domain.TransferToken {
+	clone := *token
+	clone.ConsumedAt = cloneTime(token.ConsumedAt)
+	return &clone
+}
@@ -0,0 +1,132 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestAuditRepository(t *testing.T, newRepository func(t *testing.T) ports.AuditRepository) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	newEntry := func(id string, sessionID string, brewID string, at time.Time) *domain.AuditEntry {
+		return &domain.AuditEntry{
+			ID:        id,
+			SessionID: sessionID,
+			Operation: domain.AuditRecordAdded,
+			BrewID:    brewID,
+			RecordID:  "record-" + id,
+			At:        at,
+		}
+	}
+
+	t.Run("Append keeps every field", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		entry := newEntry("entry-1", "session-1", "brew-1", base)
+		entry.ShareToken = "token-1"
+		if err := repo.Append(ctx, entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+
+		page, err := repo.GetByBrewID(ctx, "brew-1", nil, 0)
+		if err != nil {
+			t.Fatalf("GetByBrewID() error = %v", err)
+		}
+		if len(page.Items) != 1 || page.TotalCount != 1 {
+			t.Fatalf("GetByBrewID() = %+v, want one entry", page)
+		}
+		got := page.Items[0]
+		if got.SessionID != "session-1" || got.ShareToken != "token-1" || got.Operation != domain.AuditRecordAdded ||
+			got.RecordID != "record-entry-1" || !got.At.Equal(base) {
+			t.Fatalf("GetByBrewID() entry = %+v", got)
+		}
+
+		if err := repo.Append(ctx, entry); !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Append() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+	})
+
+	t.Run("GetBySessionID pages oldest first", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		entries := []*domain.AuditEntry{
+			newEntry("entry-3", "session-1", "brew-2", base.Add(2*time.Minute)),
+			newEntry("entry-1", "session-1", "brew-1", base),
+			newEntry("entry-2", "session-1", "", base.Add(time.Minute)),
+			newEntry("entry-4", "session-2", "brew-3", base),
+		}
+		for _, entry := range entries {
+			if err := repo.Append(ctx, entry); err != nil {
+				t.Fatalf("Append() error = %v", err)
+			}
+		}
+
+		first, err := repo.GetBySessionID(ctx, "session-1", nil, 2)
+		if err != nil {
+			t.Fatalf("GetBySessionID() error = %v", err)
+		}
+		if first.TotalCount != 3 || !first.HasMore || len(first.Items) != 2 ||
+			first.Items[0].ID != "entry-1" || first.Items[1].ID != "entry-2" {
+			t.Fatalf("GetBySessionID() first page = %+v", first)
+		}
+
+		second, err := repo.GetBySessionID(ctx, "session-1", first.NextPointer, 2)
+		if err != nil {
+			t.Fatalf("GetBySessionID() error = %v", err)
+		}
+		if second.HasMore || len(second.Items) != 1 || second.Items[0].ID != "entry-3" {
+			t.Fatalf("GetBySessionID() second page = %+v", second)
+		}
+
+		missing := "entry-4"
+		if _, err := repo.GetBySessionID(ctx, "session-1", &missing, 2); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetBySessionID() foreign pointer error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("GetSince follows Seq", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		// Seq follows write order, not At.
+		entries := []*domain.AuditEntry{
+			newEntry("entry-a", "session-1", "brew-1", base.Add(time.Hour)),
+			newEntry("entry-b", "session-2", "brew-2", base),
+			newEntry("entry-c", "session-1", "brew-1", base),
+			newEntry("entry-d", "session-1", "brew-1", base),
+		}
+		var lastSeq int64
+		for _, entry := range entries {
+			if err := repo.Append(ctx, entry); err != nil {
+				t.Fatalf("Append() error = %v", err)
+			}
+			if entry.Seq <= lastSeq {
+				t.Fatalf("Append() Seq = %d, want above %d", entry.Seq, lastSeq)
+			}
+			lastSeq = entry.Seq
+		}
+
+		got, err := repo.GetSince(ctx, "session-1", 0, 2)
+		if err != nil {
+			t.Fatalf("GetSince() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID != "entry-a" || got[1].ID != "entry-c" || got[1].Seq != entries[2].Seq {
+			t.Fatalf("GetSince() = %+v, want entry-a and entry-c", got)
+		}
+
+		got, err = repo.GetSince(ctx, "session-1", got[1].Seq, 2)
+		if err != nil {
+			t.Fatalf("GetSince() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != "entry-d" {
+			t.Fatalf("GetSince() = %+v, want entry-d", got)
+		}
+	})
+}
@@ -0,0 +1,472 @@
+// Package repositorytest holds the contract every storage adapter must
+// satisfy. Adapter packages run these suites from their own tests.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestBrewRepository(t *testing.T, newRepository func(t *testing.T) ports.BrewRepository) {
+	t.Run("Save and GetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		now := time.Date(2025, 3, 1, 10, 30, 0, 123000, time.UTC)
+		brew := &domain.Brew{ID: "brew-1", Name: "Скубі", SessionID: "session-1", CreatedAt: now, UpdatedAt: now}
+		if err := repo.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.ID != brew.ID || got.Name != brew.Name || got.SessionID != brew.SessionID {
+			t.Fatalf("GetByID() = %+v, want %+v", got, brew)
+		}
+		if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+			t.Fatalf("GetByID() timestamps = %v/%v, want %v", got.CreatedAt, got.UpdatedAt, now)
+		}
+	})
+
+	t.Run("Save rejects duplicate ID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		brew := &domain.Brew{ID: "brew-1", Name: "first", SessionID: "session-1"}
+		if err := repo.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		err := repo.Save(ctx, &domain.Brew{ID: "brew-1", Name: "second", SessionID: "session-1"})
+		if !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Save() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "first" {
+			t.Fatalf("GetByID() name = %s, want first", got.Name)
+		}
+	})
+
+	t.Run("GetByID returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		brew, err := repo.GetByID(context.Background(), "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() error = %v, want ErrNotFound", err)
+		}
+		if brew != nil {
+			t.Fatalf("GetByID() = %+v, want nil", brew)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		brew := &domain.Brew{ID: "brew-1", Name: "before", SessionID: "session-1"}
+		if err := repo.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		updatedAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		brew.Name = "after"
+		brew.UpdatedAt = updatedAt
+		if err := repo.Update(ctx, brew); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "after" || !got.UpdatedAt.Equal(updatedAt) {
+			t.Fatalf("GetByID() after Update = %+v", got)
+		}
+	})
+
+	t.Run("Update stores details and archive state", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		brew := &domain.Brew{ID: "brew-1", Name: "Скубі", SessionID: "session-1"}
+		if err := repo.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		archivedAt := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+		brew.VesselVolume = domain.Quantity{Amount: 3, Unit: domain.Liters}
+		brew.Location = "кухня, верхня полиця"
+		brew.ColorTag = domain.GreenTag
+		brew.ScobyOrigin = "from the market"
+		brew.ArchivedAt = &archivedAt
+		if err := repo.Update(ctx, brew); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.VesselVolume != brew.VesselVolume || got.Location != brew.Location ||
+			got.ColorTag != brew.ColorTag || got.ScobyOrigin != brew.ScobyOrigin {
+			t.Fatalf("GetByID() details = %+v, want %+v", got, brew)
+		}
+		if got.ArchivedAt == nil || !got.ArchivedAt.Equal(archivedAt) {
+			t.Fatalf("GetByID() ArchivedAt = %v, want %v", got.ArchivedAt, archivedAt)
+		}
+
+		got.ArchivedAt = nil
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if got, _ := repo.GetByID(ctx, "brew-1"); got.IsArchived() {
+			t.Fatalf("GetByID() after unarchiving = %+v, want no ArchivedAt", got)
+		}
+	})
+
+	t.Run("Update rejects a stale version", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, &domain.Brew{ID: "brew-1", Name: "before", SessionID: "session-1"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		first, _ := repo.GetByID(ctx, "brew-1")
+		second, _ := repo.GetByID(ctx, "brew-1")
+
+		first.Name = "first"
+		if err := repo.Update(ctx, first); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if first.Version != second.Version+1 {
+			t.Fatalf("Update() Version = %d, want %d", first.Version, second.Version+1)
+		}
+
+		second.Name = "second"
+		if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrConflict) {
+			t.Fatalf("Update() with a stale version error = %v, want ErrConflict", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "first" || got.Version != first.Version {
+			t.Fatalf("GetByID() after conflict = %+v, want the first edit", got)
+		}
+	})
+
+	t.Run("Update returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Update(context.Background(), &domain.Brew{ID: "missing"})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		exists, err := repo.Exists(ctx, "brew-1")
+		if err != nil || exists {
+			t.Fatalf("Exists() before Save = %v, %v, want false, nil", exists, err)
+		}
+
+		if err := repo.Save(ctx, &domain.Brew{ID: "brew-1", SessionID: "session-1"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		exists, err = repo.Exists(ctx, "brew-1")
+		if err != nil || !exists {
+			t.Fatalf("Exists() after Save = %v, %v, want true, nil", exists, err)
+		}
+	})
+
+	t.Run("returned brews are detached from storage", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		brew := &domain.Brew{ID: "brew-1", Name: "original", SessionID: "session-1"}
+		if err := repo.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		brew.Name = "mutated after save"
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		got.Name = "mutated after get"
+
+		again, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if again.Name != "original" {
+			t.Fatalf("GetByID() name = %s, want original", again.Name)
+		}
+	})
+
+	t.Run("GetBySessionID paginates in creation order", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		// Saved out of order so adapters can't rely on insertion order.
+		for _, i := range []int{3, 0, 4, 1, 2} {
+			created := base.Add(time.Duration(i) * time.Hour)
+			brew := &domain.Brew{ID: fmt.Sprintf("brew-%d", i), SessionID: "session-1", CreatedAt: created, UpdatedAt: created}
+			if err := repo.Save(ctx, brew); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		if err := repo.Save(ctx, &domain.Brew{ID: "other", SessionID: "session-2", CreatedAt: base}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		var seen []string
+		var pointer *string
+		for pages := 0; ; pages++ {
+			if pages > 5 {
+				t.Fatal("GetBySessionID() did not terminate")
+			}
+
+			page, err := repo.GetBySessionID(ctx, "session-1", pointer, 2)
+			if err != nil {
+				t.Fatalf("GetBySessionID() error = %v", err)
+			}
+			if page.TotalCount != 5 {
+				t.Fatalf("GetBySessionID() TotalCount = %d, want 5", page.TotalCount)
+			}
+			for _, brew := range page.Items {
+				seen = append(seen, brew.ID)
+			}
+			if !page.HasMore {
+				if page.NextPointer != nil {
+					t.Fatalf("GetBySessionID() last page NextPointer = %v, want nil", *page.NextPointer)
+				}
+				break
+			}
+			pointer = page.NextPointer
+		}
+
+		want := []string{"brew-0", "brew-1", "brew-2", "brew-3", "brew-4"}
+		if fmt.Sprint(seen) != fmt.Sprint(want) {
+			t.Fatalf("GetBySessionID() ids = %v, want %v", seen, want)
+		}
+	})
+
+	t.Run("GetBySessionID without limit returns everything", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			if err := repo.Save(ctx, &domain.Brew{ID: fmt.Sprintf("brew-%d", i), SessionID: "session-1"}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		page, err := repo.GetBySessionID(ctx, "session-1", nil, 0)
+		if err != nil {
+			t.Fatalf("GetBySessionID() error = %v", err)
+		}
+		if len(page.Items) != 3 || page.HasMore {
+			t.Fatalf("GetBySessionID() = %d items, HasMore %v, want 3, false", len(page.Items), page.HasMore)
+		}
+	})
+
+	t.Run("GetBySessionID for unknown session is empty", func(t *testing.T) {
+		repo := newRepository(t)
+
+		page, err := repo.GetBySessionID(context.Background(), "missing", nil, 10)
+		if err != nil {
+			t.Fatalf("GetBySessionID() error = %v", err)
+		}
+		if len(page.Items) != 0 || page.TotalCount != 0 || page.HasMore {
+			t.Fatalf("GetBySessionID() = %+v, want empty page", page)
+		}
+	})
+
+	t.Run("GetBySessionID rejects unknown pointer", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, &domain.Brew{ID: "brew-1", SessionID: "session-1"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Save(ctx, &domain.Brew{ID: "brew-2", SessionID: "session-2"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		for _, pointer := range []string{"missing", "brew-2"} {
+			_, err := repo.GetBySessionID(ctx, "session-1", &pointer, 10)
+			if !errors.Is(err, domain.ErrNotFound) {
+				t.Fatalf("GetBySessionID(pointer=%s) error = %v, want ErrNotFound", pointer, err)
+			}
+		}
+	})
+	t.Run("Query filters by status and search", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		archivedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		for _, brew := range []*domain.Brew{
+			{ID: "brew-1", Name: "Чайний гриб", SessionID: "session-1"},
+			{ID: "brew-2", Name: "Зелений чай", SessionID: "session-1", ArchivedAt: &archivedAt},
+			{ID: "brew-3", Name: "Kombucha", SessionID: "session-1"},
+			{ID: "brew-4", Name: "Чай", SessionID: "session-2"},
+		} {
+			if err := repo.Save(ctx, brew); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		tests := []struct {
+			name  string
+			query domain.BrewQuery
+			want  []string
+		}{
+			{name: "everything", query: domain.BrewQuery{}, want: []string{"brew-1", "brew-2", "brew-3"}},
+			{name: "active", query: domain.BrewQuery{Status: domain.ActiveBrewStatus}, want: []string{"brew-1", "brew-3"}},
+			{name: "archived", query: domain.BrewQuery{Status: domain.ArchivedBrewStatus}, want: []string{"brew-2"}},
+			{name: "Cyrillic substring in any case", query: domain.BrewQuery{Search: "ЧАЙ"}, want: []string{"brew-1", "brew-2"}},
+			{name: "without the breve", query: domain.BrewQuery{Search: "чаи"}, want: []string{"brew-1", "brew-2"}},
+			{name: "with a typo", query: domain.BrewQuery{Search: "kombuhca"}, want: []string{"brew-3"}},
+			{name: "search and status", query: domain.BrewQuery{Search: "чай", Status: domain.ActiveBrewStatus}, want: []string{"brew-1"}},
+			{name: "no match", query: domain.BrewQuery{Search: "kefir"}, want: nil},
+		}
+		for _, tt := range tests {
+			tt.query.SessionID = "session-1"
+			tt.query.Sort = domain.SortByCreated
+			page, err := repo.Query(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("Query(%s) error = %v", tt.name, err)
+			}
+			var got []string
+			for _, brew := range page.Items {
+				got = append(got, brew.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || page.TotalCount != len(tt.want) {
+				t.Errorf("Query(%s) = %v of %d, want %v", tt.name, got, page.TotalCount, tt.want)
+			}
+		}
+	})
+
+	t.Run("Query sorts and paginates in either direction", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		// brew-i is updated (4-i) hours in; brew-0 and brew-2 have nothing
+		// planned, brew-3 is due before brew-1.
+		for i := range 4 {
+			updated := base.Add(time.Duration(4-i) * time.Hour)
+			brew := &domain.Brew{ID: fmt.Sprintf("brew-%d", i), SessionID: "session-1", CreatedAt: base, UpdatedAt: updated}
+			if err := repo.Save(ctx, brew); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		for id, due := range map[string]time.Time{"brew-1": base.Add(48 * time.Hour), "brew-3": base.Add(24 * time.Hour)} {
+			if err := repo.SetNextAction(ctx, id, &due); err != nil {
+				t.Fatalf("SetNextAction() error = %v", err)
+			}
+		}
+
+		tests := []struct {
+			sort       domain.BrewSort
+			descending bool
+			want       []string
+		}{
+			{sort: domain.SortByCreated, want: []string{"brew-0", "brew-1", "brew-2", "brew-3"}},
+			{sort: domain.SortByCreated, descending: true, want: []string{"brew-3", "brew-2", "brew-1", "brew-0"}},
+			{sort: domain.SortByUpdated, want: []string{"brew-3", "brew-2", "brew-1", "brew-0"}},
+			{sort: domain.SortByUpdated, descending: true, want: []string{"brew-0", "brew-1", "brew-2", "brew-3"}},
+			{sort: domain.SortByNextAction, want: []string{"brew-3", "brew-1", "brew-0", "brew-2"}},
+			{sort: domain.SortByNextAction, descending: true, want: []string{"brew-1", "brew-3", "brew-2", "brew-0"}},
+		}
+		for _, tt := range tests {
+			var seen []string
+			var pointer *string
+			for pages := 0; ; pages++ {
+				if pages > 4 {
+					t.Fatalf("Query(%s, descending %v) did not terminate", tt.sort, tt.descending)
+				}
+				page, err := repo.Query(ctx, domain.BrewQuery{
+					SessionID:  "session-1",
+					Sort:       tt.sort,
+					Descending: tt.descending,
+					Pointer:    pointer,
+					Limit:      3,
+				})
+				if err != nil {
+					t.Fatalf("Query(%s, descending %v) error = %v", tt.sort, tt.descending, err)
+				}
+				for _, brew := range page.Items {
+					seen = append(seen, brew.ID)
+				}
+				if !page.HasMore {
+					break
+				}
+				pointer = page.NextPointer
+			}
+			if fmt.Sprint(seen) != fmt.Sprint(tt.want) {
+				t.Errorf("Query(%s, descending %v) ids = %v, want %v", tt.sort, tt.descending, seen, tt.want)
+			}
+		}
+	})
+
+	t.Run("SetNextAction keeps the version and survives Update", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, &domain.Brew{ID: "brew-1", Name: "before", SessionID: "session-1"}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		// Read before the timeline changes, as a concurrent edit would.
+		brew, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		due := time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)
+		if err := repo.SetNextAction(ctx, "brew-1", &due); err != nil {
+			t.Fatalf("SetNextAction() error = %v", err)
+		}
+		brew.Name = "after"
+		if err := repo.Update(ctx, brew); err != nil {
+			t.Fatalf("Update() after SetNextAction error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "after" || got.Version != 1 || got.NextActionAt == nil || !got.NextActionAt.Equal(due) {
+			t.Fatalf("GetByID() = %+v, want the rename at version 1 due %v", got, due)
+		}
+
+		if err := repo.SetNextAction(ctx, "brew-1", nil); err != nil {
+			t.Fatalf("SetNextAction(nil) error = %v", err)
+		}
+		if got, _ := repo.GetByID(ctx, "brew-1"); got.NextActionAt != nil {
+			t.Fatalf("GetByID() NextActionAt = %v, want nil", got.NextActionAt)
+		}
+		if err := repo.SetNextAction(ctx, "missing", &due); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("SetNextAction(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+}
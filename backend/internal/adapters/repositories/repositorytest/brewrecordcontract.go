@@ -0,0 +1,338 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestBrewRecordRepository(t *testing.T, newRepository func(t *testing.T) ports.BrewRecordRepository) {
+	base := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	newRecord := func(id string, brewID string, created time.Time) *domain.BrewRecord {
+		return &domain.BrewRecord{
+			ID:        id,
+			BrewID:    brewID,
+			SessionID: "session-1",
+			Recipe: domain.Recipe{
+				Water:     domain.Quantity{Amount: 3, Unit: domain.Liters},
+				SugarType: domain.CaneSugar,
+				Sugar:     domain.Quantity{Amount: 240, Unit: domain.Grams},
+				TeaType:   domain.BlackTea,
+				Tea:       domain.Quantity{Amount: 2, Unit: domain.Tablespoons},
+				Extras: []domain.Ingredient{
+					{Name: "каркаде", Quantity: domain.Quantity{Amount: 10, Unit: domain.Grams}},
+					{Name: "ginger", Quantity: domain.Quantity{Amount: 3, Unit: domain.Pieces}},
+				},
+			},
+			CreatedAt: created,
+		}
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		record := newRecord("record-1", "brew-1", base)
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.BrewID != "brew-1" || got.SessionID != "session-1" || !got.CreatedAt.Equal(base) {
+			t.Fatalf("GetByID() = %+v", got)
+		}
+		if !reflect.DeepEqual(got.Recipe, record.Recipe) {
+			t.Fatalf("GetByID() recipe = %+v, want %+v", got.Recipe, record.Recipe)
+		}
+	})
+
+	t.Run("Save keeps a recipe without extras empty", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		record := newRecord("record-1", "brew-1", base)
+		record.Recipe.Extras = nil
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if len(got.Recipe.Extras) != 0 {
+			t.Fatalf("GetByID() extras = %+v, want none", got.Recipe.Extras)
+		}
+	})
+
+	t.Run("Save rejects duplicate ID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newRecord("record-1", "brew-1", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		err := repo.Save(ctx, newRecord("record-1", "brew-2", base))
+		if !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Save() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+	})
+
+	t.Run("GetByID returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		record, err := repo.GetByID(context.Background(), "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() error = %v, want ErrNotFound", err)
+		}
+		if record != nil {
+			t.Fatalf("GetByID() = %+v, want nil", record)
+		}
+	})
+
+	t.Run("returned records are detached from storage", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newRecord("record-1", "brew-1", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		got.Recipe.Extras[0].Name = "mutated"
+
+		again, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if again.Recipe.Extras[0].Name != "каркаде" {
+			t.Fatalf("GetByID() extra = %s, stored record was mutated", again.Recipe.Extras[0].Name)
+		}
+	})
+
+	t.Run("GetByBrewID paginates in creation order", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for _, i := range []int{2, 0, 3, 1} {
+			record := newRecord(fmt.Sprintf("record-%d", i), "brew-1", base.Add(time.Duration(i)*time.Hour))
+			if err := repo.Save(ctx, record); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		if err := repo.Save(ctx, newRecord("other", "brew-2", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		var seen []string
+		var pointer *string
+		for pages := 0; ; pages++ {
+			if pages > 4 {
+				t.Fatal("GetByBrewID() did not terminate")
+			}
+
+			page, err := repo.GetByBrewID(ctx, "brew-1", pointer, 3)
+			if err != nil {
+				t.Fatalf("GetByBrewID() error = %v", err)
+			}
+			if page.TotalCount != 4 {
+				t.Fatalf("GetByBrewID() TotalCount = %d, want 4", page.TotalCount)
+			}
+			for _, record := range page.Items {
+				seen = append(seen, record.ID)
+			}
+			if !page.HasMore {
+				break
+			}
+			pointer = page.NextPointer
+		}
+
+		want := []string{"record-0", "record-1", "record-2", "record-3"}
+		if fmt.Sprint(seen) != fmt.Sprint(want) {
+			t.Fatalf("GetByBrewID() ids = %v, want %v", seen, want)
+		}
+	})
+
+	t.Run("GetByBrewID rejects unknown pointer", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newRecord("other", "brew-2", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		for _, pointer := range []string{"missing", "other"} {
+			_, err := repo.GetByBrewID(ctx, "brew-1", &pointer, 10)
+			if !errors.Is(err, domain.ErrNotFound) {
+				t.Fatalf("GetByBrewID(pointer=%s) error = %v, want ErrNotFound", pointer, err)
+			}
+		}
+	})
+	t.Run("submitted records are immutable", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		record := newRecord("record-1", "brew-1", base)
+		submittedAt := base.Add(time.Minute)
+		record.SubmittedAt = &submittedAt
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.SubmittedAt == nil || !got.SubmittedAt.Equal(submittedAt) {
+			t.Fatalf("GetByID() SubmittedAt = %v, want %v", got.SubmittedAt, submittedAt)
+		}
+
+		record.Recipe.Water.Amount = 5
+		if err := repo.Update(ctx, record); !errors.Is(err, domain.ErrImmutable) {
+			t.Fatalf("Update() error = %v, want ErrImmutable", err)
+		}
+		if err := repo.Delete(ctx, "record-1"); !errors.Is(err, domain.ErrImmutable) {
+			t.Fatalf("Delete() error = %v, want ErrImmutable", err)
+		}
+
+		got, err = repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() after rejected writes error = %v", err)
+		}
+		if got.Recipe.Water.Amount != 3 {
+			t.Fatalf("GetByID() water = %v, want 3", got.Recipe.Water.Amount)
+		}
+	})
+
+	t.Run("draft records can be updated and deleted", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		record := newRecord("record-1", "brew-1", base)
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		record.Recipe.Water.Amount = 5
+		submittedAt := base.Add(time.Minute)
+		record.SubmittedAt = &submittedAt
+		if err := repo.Update(ctx, record); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Recipe.Water.Amount != 5 || got.SubmittedAt == nil {
+			t.Fatalf("GetByID() after Update = %+v", got)
+		}
+		if err := repo.Delete(ctx, "record-1"); !errors.Is(err, domain.ErrImmutable) {
+			t.Fatalf("Delete() after submit error = %v, want ErrImmutable", err)
+		}
+
+		draft := newRecord("record-2", "brew-1", base)
+		if err := repo.Save(ctx, draft); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Delete(ctx, "record-2"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "record-2"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() after Delete error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Update and Delete return ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Update(ctx, newRecord("missing", "brew-1", base)); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update() error = %v, want ErrNotFound", err)
+		}
+		if err := repo.Delete(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Delete() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("AppendNote and GetNotes", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		record := newRecord("record-1", "brew-1", base)
+		submittedAt := base
+		record.SubmittedAt = &submittedAt
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		notes, err := repo.GetNotes(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetNotes() error = %v", err)
+		}
+		if len(notes) != 0 {
+			t.Fatalf("GetNotes() = %d notes, want 0", len(notes))
+		}
+
+		texts := []string{"first taste: too sweet", "друга проба: ідеально", "bottled"}
+		for i, text := range texts {
+			note := &domain.RecordNote{
+				ID:        fmt.Sprintf("note-%d", i),
+				RecordID:  "record-1",
+				SessionID: fmt.Sprintf("session-%d", i),
+				Text:      text,
+				CreatedAt: base.Add(time.Duration(i) * time.Hour),
+			}
+			if err := repo.AppendNote(ctx, note); err != nil {
+				t.Fatalf("AppendNote() error = %v", err)
+			}
+		}
+
+		duplicate := &domain.RecordNote{ID: "note-0", RecordID: "record-1", Text: "again", CreatedAt: base}
+		if err := repo.AppendNote(ctx, duplicate); !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("AppendNote() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+
+		notes, err = repo.GetNotes(ctx, "record-1")
+		if err != nil {
+			t.Fatalf("GetNotes() error = %v", err)
+		}
+		if len(notes) != len(texts) {
+			t.Fatalf("GetNotes() = %d notes, want %d", len(notes), len(texts))
+		}
+		for i, note := range notes {
+			if note.Text != texts[i] || note.SessionID != fmt.Sprintf("session-%d", i) {
+				t.Fatalf("GetNotes()[%d] = %+v, want text %q", i, note, texts[i])
+			}
+			if !note.CreatedAt.Equal(base.Add(time.Duration(i) * time.Hour)) {
+				t.Fatalf("GetNotes()[%d] CreatedAt = %v", i, note.CreatedAt)
+			}
+		}
+	})
+
+	t.Run("notes require an existing record", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		note := &domain.RecordNote{ID: "note-1", RecordID: "missing", Text: "orphan", CreatedAt: base}
+		if err := repo.AppendNote(ctx, note); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("AppendNote() error = %v, want ErrNotFound", err)
+		}
+		if _, err := repo.GetNotes(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetNotes() error = %v, want ErrNotFound", err)
+		}
+	})
+}
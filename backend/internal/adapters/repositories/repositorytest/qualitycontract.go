@@ -0,0 +1,136 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestQualityRepository(t *testing.T, newRepository func(t *testing.T) ports.QualityRepository) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	newEvaluation := func(id string, brewID string, createdAt time.Time) *domain.QualityEvaluation {
+		return &domain.QualityEvaluation{
+			ID:          id,
+			RecordID:    "record-" + id,
+			BrewID:      brewID,
+			SessionID:   "session-1",
+			Score:       domain.QualityScore{Rating: 4, Sweetness: 2, Fizz: 5},
+			Notes:       "Кисленький",
+			Suggestions: "less sugar",
+			CreatedAt:   createdAt,
+		}
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		ph := 3.2
+		evaluation := newEvaluation("evaluation-1", "brew-1", base)
+		evaluation.Score.PH = &ph
+		if err := repo.Save(ctx, evaluation); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "evaluation-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.RecordID != "record-evaluation-1" || got.BrewID != "brew-1" || got.Notes != "Кисленький" || got.Suggestions != "less sugar" {
+			t.Fatalf("GetByID() = %+v", got)
+		}
+		if got.Score.Rating != 4 || got.Score.Sweetness != 2 || got.Score.Fizz != 5 {
+			t.Fatalf("GetByID() Score = %+v", got.Score)
+		}
+		if got.Score.PH == nil || *got.Score.PH != ph {
+			t.Fatalf("GetByID() PH = %v, want %v", got.Score.PH, ph)
+		}
+		if !got.CreatedAt.Equal(base) {
+			t.Fatalf("GetByID() CreatedAt = %v, want %v", got.CreatedAt, base)
+		}
+	})
+
+	t.Run("Save keeps missing pH", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newEvaluation("evaluation-1", "brew-1", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, "evaluation-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Score.PH != nil {
+			t.Fatalf("GetByID() PH = %v, want nil", *got.Score.PH)
+		}
+	})
+
+	t.Run("Save rejects duplicate ID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newEvaluation("evaluation-1", "brew-1", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		err := repo.Save(ctx, newEvaluation("evaluation-1", "brew-2", base))
+		if !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Save() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+	})
+
+	t.Run("GetByID returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		if _, err := repo.GetByID(context.Background(), "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("GetByBrewID is oldest first", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for _, i := range []int{2, 0, 1} {
+			evaluation := newEvaluation(fmt.Sprintf("evaluation-%d", i), "brew-1", base.Add(time.Duration(i)*time.Hour))
+			if err := repo.Save(ctx, evaluation); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		if err := repo.Save(ctx, newEvaluation("other", "brew-2", base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		evaluations, err := repo.GetByBrewID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByBrewID() error = %v", err)
+		}
+
+		var ids []string
+		for _, evaluation := range evaluations {
+			ids = append(ids, evaluation.ID)
+		}
+		want := []string{"evaluation-0", "evaluation-1", "evaluation-2"}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Fatalf("GetByBrewID() ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("GetByBrewID for unknown brew is empty", func(t *testing.T) {
+		repo := newRepository(t)
+
+		evaluations, err := repo.GetByBrewID(context.Background(), "missing")
+		if err != nil {
+			t.Fatalf("GetByBrewID() error = %v", err)
+		}
+		if len(evaluations) != 0 {
+			t.Fatalf("GetByBrewID() = %d evaluations, want 0", len(evaluations))
+		}
+	})
+}
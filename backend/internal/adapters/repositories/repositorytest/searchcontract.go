@@ -0,0 +1,131 @@
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestSearchRepository(t *testing.T, newRepository func(t *testing.T) ports.SearchRepository) {
+	base := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	index := func(t *testing.T, repo ports.SearchRepository, documents ...*domain.SearchDocument) {
+		t.Helper()
+		for _, document := range documents {
+			if err := repo.Index(context.Background(), document); err != nil {
+				t.Fatalf("Index(%s) error = %v", document.ID, err)
+			}
+		}
+	}
+	search := func(t *testing.T, repo ports.SearchRepository, sessionID string, query string) []string {
+		t.Helper()
+		hits, err := repo.Search(context.Background(), sessionID, query, 10)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", query, err)
+		}
+		var ids []string
+		for _, hit := range hits {
+			ids = append(ids, hit.Document.ID)
+		}
+		return ids
+	}
+
+	t.Run("Search matches folded word prefixes of every term", func(t *testing.T) {
+		repo := newRepository(t)
+		index(t, repo,
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "brew-1", SessionID: "session-1", BrewID: "brew-1", Text: "Чайний гриб", At: base},
+			&domain.SearchDocument{Kind: domain.RecordSearchKind, ID: "record-1", SessionID: "session-1", BrewID: "brew-1", RecordID: "record-1", Text: "Hibiscus, ginger, green tea", At: base},
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-1", SessionID: "session-1", BrewID: "brew-1", RecordID: "record-1", Text: "Très pétillant after the hibiscus", At: base},
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "brew-2", SessionID: "session-2", BrewID: "brew-2", Text: "Hibiscus jar", At: base},
+		)
+
+		tests := []struct {
+			query string
+			want  []string
+		}{
+			{query: "ЧАЙНИЙ", want: []string{"brew-1"}},
+			{query: "чаинии", want: []string{"brew-1"}},
+			{query: "petillant", want: []string{"note-1"}},
+			{query: "gin", want: []string{"record-1"}},
+			{query: "hibiscus ginger", want: []string{"record-1"}},
+			{query: "biscus", want: nil},
+			{query: "kefir", want: nil},
+			{query: "?!", want: nil},
+		}
+		for _, tt := range tests {
+			if got := search(t, repo, "session-1", tt.query); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("Search ranks denser matches first and keeps every field", func(t *testing.T) {
+		repo := newRepository(t)
+		index(t, repo,
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-1", SessionID: "session-1", BrewID: "brew-1", RecordID: "record-1", Text: "Hibiscus went in late, next to a lot of other fruit and a cinnamon stick", At: base},
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-2", SessionID: "session-1", BrewID: "brew-2", RecordID: "record-2", Text: "Hibiscus, more hibiscus", At: base.Add(time.Hour)},
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-3", SessionID: "session-1", BrewID: "brew-3", RecordID: "record-3", Text: "Plain black tea", At: base},
+		)
+
+		hits, err := repo.Search(context.Background(), "session-1", "hibiscus", 10)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(hits) != 2 || hits[0].Document.ID != "note-2" || hits[1].Document.ID != "note-1" {
+			t.Fatalf("Search() = %+v, want note-2 then note-1", hits)
+		}
+		if hits[0].Score <= hits[1].Score {
+			t.Errorf("Search() scores = %v, %v, want descending", hits[0].Score, hits[1].Score)
+		}
+		want := domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-2", SessionID: "session-1", BrewID: "brew-2", RecordID: "record-2", Text: "Hibiscus, more hibiscus", At: base.Add(time.Hour)}
+		if got := hits[0].Document; got != want {
+			t.Errorf("Search() document = %+v, want %+v", got, want)
+		}
+
+		hits, err = repo.Search(context.Background(), "session-1", "hibiscus", 1)
+		if err != nil || len(hits) != 1 {
+			t.Fatalf("Search(limit 1) = %d hits, %v, want 1", len(hits), err)
+		}
+	})
+
+	t.Run("Index replaces the document of the same kind and ID", func(t *testing.T) {
+		repo := newRepository(t)
+		index(t, repo,
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "jar-1", SessionID: "session-1", BrewID: "jar-1", Text: "Kombucha", At: base},
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-1", SessionID: "session-1", BrewID: "jar-1", RecordID: "record-1", Text: "Kombucha note", At: base},
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "jar-1", SessionID: "session-1", BrewID: "jar-1", Text: "Jun", At: base},
+		)
+
+		if got := search(t, repo, "session-1", "kombucha"); fmt.Sprint(got) != "[note-1]" {
+			t.Errorf("Search(old name) = %v, want only the note", got)
+		}
+		if got := search(t, repo, "session-1", "jun"); fmt.Sprint(got) != "[jar-1]" {
+			t.Errorf("Search(new name) = %v, want [jar-1]", got)
+		}
+	})
+
+	t.Run("MoveBrew moves every document of the jar", func(t *testing.T) {
+		repo := newRepository(t)
+		index(t, repo,
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "brew-1", SessionID: "session-1", BrewID: "brew-1", Text: "Hibiscus jar", At: base},
+			&domain.SearchDocument{Kind: domain.RecordSearchKind, ID: "record-1", SessionID: "session-1", BrewID: "brew-1", RecordID: "record-1", Text: "hibiscus", At: base},
+			&domain.SearchDocument{Kind: domain.NoteSearchKind, ID: "note-1", SessionID: "session-1", BrewID: "brew-1", RecordID: "record-1", Text: "hibiscus again", At: base},
+			&domain.SearchDocument{Kind: domain.BrewSearchKind, ID: "brew-2", SessionID: "session-1", BrewID: "brew-2", Text: "Hibiscus too", At: base},
+		)
+
+		if err := repo.MoveBrew(context.Background(), "brew-1", "session-2"); err != nil {
+			t.Fatalf("MoveBrew() error = %v", err)
+		}
+
+		if got := search(t, repo, "session-2", "hibiscus"); len(got) != 3 {
+			t.Errorf("Search(new owner) = %v, want the jar, its record and its note", got)
+		}
+		if got := search(t, repo, "session-1", "hibiscus"); fmt.Sprint(got) != "[brew-2]" {
+			t.Errorf("Search(old owner) = %v, want [brew-2]", got)
+		}
+	})
+}
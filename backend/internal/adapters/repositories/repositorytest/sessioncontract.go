@@ -0,0 +1,293 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestSessionRepository(t *testing.T, newRepository func(t *testing.T) ports.SessionRepository) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	expires := now.Add(24 * time.Hour)
+
+	newSession := func(id string) *domain.Session {
+		return &domain.Session{
+			ID:           id,
+			CreatedAt:    now,
+			LastAccessed: now,
+			ExpiresAt:    &expires,
+			IsActive:     true,
+			ShareTokens: []domain.ShareToken{
+				{Token: id + "-token", Scope: domain.ReadOnlyScope, CreatedAt: now, ExpiresAt: &expires, IsActive: true},
+			},
+		}
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !got.IsActive || !got.CreatedAt.Equal(now) || !got.LastAccessed.Equal(now) {
+			t.Fatalf("GetByID() = %+v", got)
+		}
+		if got.ExpiresAt == nil || !got.ExpiresAt.Equal(expires) {
+			t.Fatalf("GetByID() ExpiresAt = %v, want %v", got.ExpiresAt, expires)
+		}
+		if len(got.ShareTokens) != 1 {
+			t.Fatalf("GetByID() ShareTokens = %+v, want 1 token", got.ShareTokens)
+		}
+		token := got.ShareTokens[0]
+		if token.Token != "session-1-token" || token.Scope != domain.ReadOnlyScope || !token.IsActive {
+			t.Fatalf("GetByID() token = %+v", token)
+		}
+	})
+
+	t.Run("Save rejects duplicate ID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Save(ctx, newSession("session-1")); !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Save() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+	})
+
+	t.Run("GetByID returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		session, err := repo.GetByID(context.Background(), "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() error = %v, want ErrNotFound", err)
+		}
+		if session != nil {
+			t.Fatalf("GetByID() = %+v, want nil", session)
+		}
+	})
+
+	t.Run("GetByShareToken", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for _, id := range []string{"session-1", "session-2"} {
+			if err := repo.Save(ctx, newSession(id)); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		got, err := repo.GetByShareToken(ctx, "session-2-token")
+		if err != nil {
+			t.Fatalf("GetByShareToken() error = %v", err)
+		}
+		if got.ID != "session-2" || len(got.ShareTokens) != 1 {
+			t.Fatalf("GetByShareToken() = %+v", got)
+		}
+
+		if _, err := repo.GetByShareToken(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByShareToken() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("GetExpired", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		idle := newSession("idle")
+		idle.ExpiresAt = nil
+		idle.LastAccessed = now.Add(-48 * time.Hour)
+		past := newSession("past-expiry")
+		pastExpiry := now.Add(-time.Minute)
+		past.ExpiresAt = &pastExpiry
+		fresh := newSession("fresh")
+		for _, session := range []*domain.Session{idle, past, fresh} {
+			if err := repo.Save(ctx, session); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		expired, err := repo.GetExpired(ctx, now, 24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetExpired() error = %v", err)
+		}
+		if len(expired) != 2 || expired[0].ID != "idle" || expired[1].ID != "past-expiry" {
+			t.Fatalf("GetExpired() = %+v, want idle and past-expiry", expired)
+		}
+		if len(expired[0].ShareTokens) != 1 {
+			t.Fatalf("GetExpired() share tokens = %+v, want them loaded", expired[0].ShareTokens)
+		}
+	})
+
+	t.Run("Update replaces fields and share tokens", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		session := newSession("session-1")
+		if err := repo.Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		later := now.Add(time.Hour)
+		session.LastAccessed = later
+		session.IsActive = false
+		session.ExpiresAt = nil
+		session.ShareTokens[0].IsActive = false
+		session.ShareTokens = append(session.ShareTokens, domain.ShareToken{
+			Token: "second", Scope: domain.ReadWriteScope, CreatedAt: later, IsActive: true,
+		})
+		if err := repo.Update(ctx, session); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.IsActive || !got.LastAccessed.Equal(later) || got.ExpiresAt != nil {
+			t.Fatalf("GetByID() after Update = %+v", got)
+		}
+		if len(got.ShareTokens) != 2 {
+			t.Fatalf("GetByID() ShareTokens = %+v, want 2 tokens", got.ShareTokens)
+		}
+		if got.ShareTokens[0].IsActive {
+			t.Fatal("GetByID() first token IsActive = true, want false")
+		}
+		if got.ShareTokens[1].Token != "second" || got.ShareTokens[1].Scope != domain.ReadWriteScope {
+			t.Fatalf("GetByID() second token = %+v", got.ShareTokens[1])
+		}
+	})
+
+	t.Run("Update rejects a stale version", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		first, _ := repo.GetByID(ctx, "session-1")
+		second, _ := repo.GetByID(ctx, "session-1")
+
+		first.IsActive = false
+		if err := repo.Update(ctx, first); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if first.Version != second.Version+1 {
+			t.Fatalf("Update() Version = %d, want %d", first.Version, second.Version+1)
+		}
+
+		second.ShareTokens = nil
+		if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrConflict) {
+			t.Fatalf("Update() with a stale version error = %v, want ErrConflict", err)
+		}
+
+		got, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.IsActive || len(got.ShareTokens) != 1 {
+			t.Fatalf("GetByID() after conflict = %+v, want the first edit", got)
+		}
+	})
+
+	t.Run("Update returns ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Update(context.Background(), newSession("missing"))
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Touch keeps the version", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		before, _ := repo.GetByID(ctx, "session-1")
+
+		later := now.Add(time.Hour)
+		if err := repo.Touch(ctx, "session-1", later); err != nil {
+			t.Fatalf("Touch() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !got.LastAccessed.Equal(later) || got.Version != before.Version {
+			t.Fatalf("GetByID() after Touch = %+v, want LastAccessed %v and version %d", got, later, before.Version)
+		}
+		if err := repo.Touch(ctx, "missing", later); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Touch() missing error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Delete(ctx, "session-1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "session-1"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() after Delete error = %v, want ErrNotFound", err)
+		}
+
+		// Deleting is idempotent so retries after a crash are harmless.
+		if err := repo.Delete(ctx, "session-1"); err != nil {
+			t.Fatalf("Delete() of missing session error = %v, want nil", err)
+		}
+
+		// The ID is free again, including its share token.
+		if err := repo.Save(ctx, newSession("session-1")); err != nil {
+			t.Fatalf("Save() after Delete error = %v", err)
+		}
+	})
+
+	t.Run("returned sessions are detached from storage", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		session := newSession("session-1")
+		if err := repo.Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		session.ShareTokens[0].IsActive = false
+
+		got, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		got.IsActive = false
+		got.ShareTokens[0].Scope = domain.ReadWriteScope
+		*got.ExpiresAt = now
+
+		again, err := repo.GetByID(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !again.IsActive || !again.ShareTokens[0].IsActive || again.ShareTokens[0].Scope != domain.ReadOnlyScope {
+			t.Fatalf("GetByID() = %+v, stored session was mutated", again)
+		}
+		if !again.ExpiresAt.Equal(expires) {
+			t.Fatalf("GetByID() ExpiresAt = %v, want %v", again.ExpiresAt, expires)
+		}
+	})
+}
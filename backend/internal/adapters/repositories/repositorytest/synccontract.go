@@ -0,0 +1,117 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestSyncRepository(t *testing.T, newRepository func(t *testing.T) ports.SyncRepository) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	pending := func(operationID string, receivedAt time.Time) *domain.SyncResult {
+		return &domain.SyncResult{
+			OperationID: operationID,
+			SessionID:   "session-1",
+			Type:        domain.SyncCreateBrew,
+			Status:      domain.SyncPending,
+			ReceivedAt:  receivedAt,
+		}
+	}
+
+	t.Run("Claim stores a pending operation once", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		_, claimed, err := repo.Claim(ctx, pending("op-1", now), now.Add(-time.Minute))
+		if err != nil || !claimed {
+			t.Fatalf("Claim() = %v, %v, want claimed", claimed, err)
+		}
+
+		stored, claimed, err := repo.Claim(ctx, pending("op-1", now), now.Add(-time.Minute))
+		if err != nil {
+			t.Fatalf("Claim() again error = %v", err)
+		}
+		if claimed || stored.Status != domain.SyncPending {
+			t.Fatalf("Claim() again = %+v, %v, want the pending claim back", stored, claimed)
+		}
+
+		other := pending("op-1", now)
+		other.SessionID = "session-2"
+		if _, claimed, err := repo.Claim(ctx, other, now.Add(-time.Minute)); err != nil || !claimed {
+			t.Fatalf("Claim() from another session = %v, %v, want claimed", claimed, err)
+		}
+	})
+
+	t.Run("Complete keeps the outcome", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		result := pending("op-1", now)
+		if _, _, err := repo.Claim(ctx, result, now); err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		result.Status = domain.SyncApplied
+		result.EntityID = "brew-1"
+		if err := repo.Complete(ctx, result); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+
+		got, err := repo.Get(ctx, "session-1", "op-1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status != domain.SyncApplied || got.EntityID != "brew-1" || got.Type != domain.SyncCreateBrew ||
+			!got.ReceivedAt.Equal(now) {
+			t.Fatalf("Get() = %+v", got)
+		}
+
+		// A finished operation is never claimed again, however old it is.
+		stored, claimed, err := repo.Claim(ctx, pending("op-1", now.Add(time.Hour)), now.Add(time.Hour))
+		if err != nil || claimed || stored.Status != domain.SyncApplied {
+			t.Fatalf("Claim() after Complete = %+v, %v, %v", stored, claimed, err)
+		}
+		if err := repo.Release(ctx, "session-1", "op-1"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if _, err := repo.Get(ctx, "session-1", "op-1"); err != nil {
+			t.Fatalf("Get() after Release error = %v, want the outcome kept", err)
+		}
+	})
+
+	t.Run("Claim takes over a stale pending operation", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, _, err := repo.Claim(ctx, pending("op-1", now), now); err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		later := now.Add(time.Hour)
+		stored, claimed, err := repo.Claim(ctx, pending("op-1", later), later.Add(-time.Minute))
+		if err != nil || !claimed || !stored.ReceivedAt.Equal(later) {
+			t.Fatalf("Claim() stale = %+v, %v, %v, want claimed", stored, claimed, err)
+		}
+	})
+
+	t.Run("Release forgets a pending operation", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, _, err := repo.Claim(ctx, pending("op-1", now), now); err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if err := repo.Release(ctx, "session-1", "op-1"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if _, err := repo.Get(ctx, "session-1", "op-1"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get() after Release error = %v, want ErrNotFound", err)
+		}
+		if err := repo.Complete(ctx, pending("op-1", now)); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Complete() after Release error = %v, want ErrNotFound", err)
+		}
+	})
+}
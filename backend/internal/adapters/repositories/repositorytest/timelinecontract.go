@@ -0,0 +1,145 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func TestTimelineRepository(t *testing.T, newRepository func(t *testing.T) ports.TimelineRepository) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	newEvent := func(id string, brewID string, eventType domain.TimelineEventType, at time.Time) *domain.TimelineEvent {
+		return &domain.TimelineEvent{
+			ID:        id,
+			BrewID:    brewID,
+			SessionID: "session-1",
+			Type:      eventType,
+			Title:     "Подія " + id,
+			At:        at,
+			CreatedAt: base,
+		}
+	}
+
+	t.Run("Save and GetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		completedAt := base.Add(time.Hour)
+		event := newEvent("event-1", "brew-1", domain.RefillEvent, base)
+		event.CompletedAt = &completedAt
+		if err := repo.Save(ctx, event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.BrewID != "brew-1" || got.Type != domain.RefillEvent || got.Title != "Подія event-1" {
+			t.Fatalf("GetByID() = %+v", got)
+		}
+		if !got.At.Equal(base) || !got.CreatedAt.Equal(base) {
+			t.Fatalf("GetByID() times = %v/%v, want %v", got.At, got.CreatedAt, base)
+		}
+		if got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+			t.Fatalf("GetByID() CompletedAt = %v, want %v", got.CompletedAt, completedAt)
+		}
+	})
+
+	t.Run("Save rejects duplicate ID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if err := repo.Save(ctx, newEvent("event-1", "brew-1", domain.StartEvent, base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		err := repo.Save(ctx, newEvent("event-1", "brew-1", domain.CustomEvent, base))
+		if !errors.Is(err, domain.ErrAlreadyExists) {
+			t.Fatalf("Save() duplicate error = %v, want ErrAlreadyExists", err)
+		}
+	})
+
+	t.Run("GetByID and Update return ErrNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByID() error = %v, want ErrNotFound", err)
+		}
+		err := repo.Update(ctx, newEvent("missing", "brew-1", domain.CustomEvent, base))
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Update completes an event", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		event := newEvent("event-1", "brew-1", domain.EstimatedHarvestEvent, base)
+		if err := repo.Save(ctx, event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		completedAt := base.Add(2 * time.Hour)
+		event.CompletedAt = &completedAt
+		if err := repo.Update(ctx, event); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, "event-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+			t.Fatalf("GetByID() CompletedAt = %v, want %v", got.CompletedAt, completedAt)
+		}
+	})
+
+	t.Run("GetByBrewID is chronological", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for _, i := range []int{2, 0, 3, 1} {
+			event := newEvent(fmt.Sprintf("event-%d", i), "brew-1", domain.CustomEvent, base.Add(time.Duration(i)*24*time.Hour))
+			if err := repo.Save(ctx, event); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		if err := repo.Save(ctx, newEvent("other", "brew-2", domain.StartEvent, base)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		events, err := repo.GetByBrewID(ctx, "brew-1")
+		if err != nil {
+			t.Fatalf("GetByBrewID() error = %v", err)
+		}
+
+		var ids []string
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		want := []string{"event-0", "event-1", "event-2", "event-3"}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Fatalf("GetByBrewID() ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("GetByBrewID for unknown brew is empty", func(t *testing.T) {
+		repo := newRepository(t)
+
+		events, err := repo.GetByBrewID(context.Background(), "missing")
+		if err != nil {
+			t.Fatalf("GetByBrewID() error = %v", err)
+		}
+		if len(events) != 0 {
+			t.Fatalf("GetByBrewID() = %d events, want 0", len(events))
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+// TransactorRepositories are backed by the storage the Transactor spans.
+type TransactorRepositories struct {
+	Transactor ports.Transactor
+	Brews      ports.BrewRepository
+	Audit      ports.AuditRepository
+	Syncs      ports.SyncRepository
+}
+
+func TestTransactor(t *testing.T, newRepositories func(t *testing.T) TransactorRepositories) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	newBrew := func(id string, name string) *domain.Brew {
+		return &domain.Brew{ID: id, Name: name, SessionID: "session-1", CreatedAt: now, UpdatedAt: now}
+	}
+
+	t.Run("failure rolls back every repository", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		// A sync operation that stores its jar and audit entry but fails
+		// before its outcome is remembered.
+		failed := errors.New("complete failed")
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			claim := &domain.SyncResult{OperationID: "op-1", SessionID: "session-1", Status: domain.SyncPending, ReceivedAt: now}
+			if _, _, err := repos.Syncs.Claim(ctx, claim, now.Add(-time.Minute)); err != nil {
+				return err
+			}
+			if err := repos.Brews.Save(ctx, newBrew("brew-1", "jar")); err != nil {
+				return err
+			}
+			entry := &domain.AuditEntry{ID: "entry-1", SessionID: "session-1", Operation: domain.AuditBrewCreated, BrewID: "brew-1", At: now}
+			if err := repos.Audit.Append(ctx, entry); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("WithinTransaction() error = %v, want %v", err, failed)
+		}
+
+		if exists, err := repos.Brews.Exists(ctx, "brew-1"); err != nil || exists {
+			t.Errorf("Exists() = %v, %v, want the jar rolled back", exists, err)
+		}
+		if entries, err := repos.Audit.GetSince(ctx, "session-1", 0, 10); err != nil || len(entries) != 0 {
+			t.Errorf("GetSince() = %d entries, %v, want the audit entry rolled back", len(entries), err)
+		}
+		if _, err := repos.Syncs.Get(ctx, "session-1", "op-1"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Get() error = %v, want the claim rolled back", err)
+		}
+	})
+
+	t.Run("nested failure undoes only its own writes", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := repos.Brews.Save(ctx, newBrew("brew-1", "kept")); err != nil {
+				return err
+			}
+			nested := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				renamed := newBrew("brew-1", "undone")
+				if err := repos.Brews.Update(ctx, renamed); err != nil {
+					return err
+				}
+				if err := repos.Brews.Save(ctx, newBrew("brew-2", "undone")); err != nil {
+					return err
+				}
+				return domain.ErrInvalid
+			})
+			if !errors.Is(nested, domain.ErrInvalid) {
+				t.Errorf("nested WithinTransaction() error = %v, want ErrInvalid", nested)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithinTransaction() error = %v", err)
+		}
+
+		for id, want := range map[string]bool{"brew-1": true, "brew-2": false} {
+			if exists, err := repos.Brews.Exists(ctx, id); err != nil || exists != want {
+				t.Errorf("Exists(%s) = %v, %v, want %v", id, exists, err, want)
+			}
+		}
+		if brew, err := repos.Brews.GetByID(ctx, "brew-1"); err != nil || brew.Name != "kept" || brew.Version != 0 {
+			t.Errorf("GetByID() = %+v, %v, want the rename undone", brew, err)
+		}
+	})
+
+	t.Run("outer failure undoes nested writes", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		failed := errors.New("outer failed")
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			nested := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return repos.Brews.Save(ctx, newBrew("brew-1", "jar"))
+			})
+			if nested != nil {
+				t.Errorf("nested WithinTransaction() error = %v", nested)
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("WithinTransaction() error = %v, want %v", err, failed)
+		}
+
+		if exists, err := repos.Brews.Exists(ctx, "brew-1"); err != nil || exists {
+			t.Errorf("Exists() = %v, %v, want the nested write rolled back", exists, err)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+// TransferRepositories are backed by the same storage, so a redeemed
+// transfer is visible through the brew and record repositories.
+type TransferRepositories struct {
+	Transfers ports.TransferRepository
+	Brews     ports.BrewRepository
+	Records   ports.BrewRecordRepository
+}
+
+func TestTransferRepository(t *testing.T, newRepositories func(t *testing.T) TransferRepositories) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) TransferRepositories {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		brew := &domain.Brew{ID: "brew-1", Name: "jar", SessionID: "session-1", CreatedAt: now, UpdatedAt: now}
+		if err := repos.Brews.Save(ctx, brew); err != nil {
+			t.Fatalf("Save() brew error = %v", err)
+		}
+		record := &domain.BrewRecord{ID: "record-1", BrewID: "brew-1", SessionID: "session-1", CreatedAt: now}
+		if err := repos.Records.Save(ctx, record); err != nil {
+			t.Fatalf("Save() record error = %v", err)
+		}
+		token := &domain.TransferToken{
+			Token:         "token-1",
+			BrewID:        "brew-1",
+			FromSessionID: "session-1",
+			CreatedAt:     now,
+			ExpiresAt:     now.Add(time.Hour),
+		}
+		if err := repos.Transfers.Save(ctx, token); err != nil {
+			t.Fatalf("Save() token error = %v", err)
+		}
+		return repos
+	}
+
+	t.Run("Save and GetByToken", func(t *testing.T) {
+		repos := setup(t)
+		ctx := context.Background()
+
+		got, err := repos.Transfers.GetByToken(ctx, "token-1")
+		if err != nil {
+			t.Fatalf("GetByToken() error = %v", err)
+		}
+		if got.BrewID != "brew-1" || got.FromSessionID != "session-1" ||
+			!got.ExpiresAt.Equal(now.Add(time.Hour)) || got.ConsumedAt != nil {
+			t.Fatalf("GetByToken() = %+v", got)
+		}
+		if _, err := repos.Transfers.GetByToken(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("GetByToken() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Redeem moves the jar and its records once", func(t *testing.T) {
+		repos := setup(t)
+		ctx := context.Background()
+
+		redeemed, err := repos.Transfers.Redeem(ctx, "token-1", "session-2", now)
+		if err != nil {
+			t.Fatalf("Redeem() error = %v", err)
+		}
+		if redeemed.ConsumedAt == nil || redeemed.ConsumedBy != "session-2" {
+			t.Fatalf("Redeem() = %+v, want it consumed by session-2", redeemed)
+		}
+
+		brew, err := repos.Brews.GetByID(ctx, "brew-1")
+		if err != nil || brew.SessionID != "session-2" {
+			t.Fatalf("GetByID() brew = %+v, %v, want it owned by session-2", brew, err)
+		}
+		record, err := repos.Records.GetByID(ctx, "record-1")
+		if err != nil || record.SessionID != "session-2" {
+			t.Fatalf("GetByID() record = %+v, %v, want it owned by session-2", record, err)
+		}
+
+		if _, err := repos.Transfers.Redeem(ctx, "token-1", "session-3", now); !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("Redeem() twice error = %v, want ErrForbidden", err)
+		}
+		stored, err := repos.Transfers.GetByToken(ctx, "token-1")
+		if err != nil || stored.ConsumedBy != "session-2" {
+			t.Fatalf("GetByToken() = %+v, %v, want the first redemption kept", stored, err)
+		}
+	})
+
+	t.Run("Redeem rejects expired tokens and changes nothing", func(t *testing.T) {
+		repos := setup(t)
+		ctx := context.Background()
+
+		if _, err := repos.Transfers.Redeem(ctx, "token-1", "session-2", now.Add(2*time.Hour)); !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("Redeem() error = %v, want ErrForbidden", err)
+		}
+		if _, err := repos.Transfers.Redeem(ctx, "missing", "session-2", now); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Redeem() error = %v, want ErrNotFound", err)
+		}
+
+		brew, err := repos.Brews.GetByID(ctx, "brew-1")
+		if err != nil || brew.SessionID != "session-1" {
+			t.Fatalf("GetByID() brew = %+v, %v, want it still owned by session-1", brew, err)
+		}
+	})
+}
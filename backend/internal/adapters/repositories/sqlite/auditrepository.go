@@ -0,0 +1,205 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.AuditRepository = (*AuditRepository)(nil)
+
+const auditEntryColumns = "seq, id, session_id, share_token, operation, brew_id, record_id, at"
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO audit_entries (id, session_id, share_token, operation, brew_id, record_id, at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		entry.ID,
+		entry.SessionID,
+		entry.ShareToken,
+		string(entry.Operation),
+		entry.BrewID,
+		entry.RecordID,
+		toUnix(entry.At),
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit entry %s: %w", entry.ID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert audit entry %s: %w", entry.ID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("audit entry %s: %w", entry.ID, domain.ErrAlreadyExists)
+	}
+
+	entry.Seq, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("insert audit entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) GetByBrewID(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	return r.list(ctx, "brew_id", brewID, pointer, limit)
+}
+
+func (r *AuditRepository) GetBySessionID(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	return r.list(ctx, "session_id", sessionID, pointer, limit)
+}
+
+func (r *AuditRepository) GetSince(
+	ctx context.Context,
+	sessionID string,
+	afterSeq int64,
+	limit int,
+) ([]*domain.AuditEntry, error) {
+	query := `SELECT ` + auditEntryColumns + ` FROM audit_entries WHERE session_id = ? AND seq > ? ORDER BY seq`
+	args := []any{sessionID, afterSeq}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries for session %s since %d: %w", sessionID, afterSeq, err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list audit entries for session %s since %d: %w", sessionID, afterSeq, err)
+	}
+	return entries, nil
+}
+
+// list pages through the entries whose column equals value; column is
+// always one of the indexed constants above, never user input.
+func (r *AuditRepository) list(
+	ctx context.Context,
+	column string,
+	value string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	result := &ports.PaginatedResult[*domain.AuditEntry]{}
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM audit_entries WHERE `+column+` = ?`,
+		value,
+	).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("count audit entries for %s: %w", value, err)
+	}
+
+	query := `SELECT ` + auditEntryColumns + ` FROM audit_entries WHERE ` + column + ` = ?`
+	args := []any{value}
+	if pointer != nil {
+		var lastAt int64
+		err := conn(ctx, r.db).QueryRowContext(
+			ctx,
+			`SELECT at FROM audit_entries WHERE id = ? AND `+column+` = ?`,
+			*pointer,
+			value,
+		).Scan(&lastAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("pointer %s: %w", *pointer, domain.ErrNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolve pointer %s: %w", *pointer, err)
+		}
+
+		query += ` AND (at, id) > (?, ?)`
+		args = append(args, lastAt, *pointer)
+	}
+	query += ` ORDER BY at, id`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit+1)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries for %s: %w", value, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		result.Items = append(result.Items, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list audit entries for %s: %w", value, err)
+	}
+
+	if limit > 0 && len(result.Items) > limit {
+		result.Items = result.Items[:limit]
+		next := result.Items[limit-1].ID
+		result.NextPointer = &next
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+func scanAuditEntry(row rowScanner) (*domain.AuditEntry, error) {
+	var entry domain.AuditEntry
+	var operation string
+	var at int64
+
+	err := row.Scan(
+		&entry.Seq,
+		&entry.ID,
+		&entry.SessionID,
+		&entry.ShareToken,
+		&operation,
+		&entry.BrewID,
+		&entry.RecordID,
+		&at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Operation = domain.AuditOperation(operation)
+	entry.At = fromUnix(at)
+	return &entry, nil
+}
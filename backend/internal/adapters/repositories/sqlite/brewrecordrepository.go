@@ -0,0 +1,385 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.BrewRecordRepository = (*BrewRecordRepository)(nil)
+
+const brewRecordColumns = `id, brew_id, session_id,
+	water_amount, water_unit,
+	sugar_type, sugar_amount, sugar_unit,
+	tea_type, tea_amount, tea_unit,
+	extras, created_at, submitted_at`
+
+type BrewRecordRepository struct {
+	db *sql.DB
+}
+
+func NewBrewRecordRepository(db *sql.DB) *BrewRecordRepository {
+	return &BrewRecordRepository{
+		db: db,
+	}
+}
+
+type ingredientRow struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+func (r *BrewRecordRepository) Save(ctx context.Context, record *domain.BrewRecord) error {
+	extras, err := encodeIngredients(record.Recipe.Extras)
+	if err != nil {
+		return fmt.Errorf("encode extras for brew record %s: %w", record.ID, err)
+	}
+
+	recipe := record.Recipe
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO brew_records (`+brewRecordColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		record.ID,
+		record.BrewID,
+		record.SessionID,
+		recipe.Water.Amount,
+		recipe.Water.Unit,
+		recipe.SugarType,
+		recipe.Sugar.Amount,
+		recipe.Sugar.Unit,
+		recipe.TeaType,
+		recipe.Tea.Amount,
+		recipe.Tea.Unit,
+		extras,
+		toUnix(record.CreatedAt),
+		toNullUnix(record.SubmittedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("insert brew record %s: %w", record.ID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert brew record %s: %w", record.ID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("brew record %s: %w", record.ID, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *BrewRecordRepository) GetByID(ctx context.Context, id string) (*domain.BrewRecord, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+brewRecordColumns+` FROM brew_records WHERE id = ?`, id)
+
+	record, err := scanBrewRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("brew record %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get brew record %s: %w", id, err)
+	}
+	return record, nil
+}
+
+func (r *BrewRecordRepository) GetByBrewID(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.BrewRecord], error) {
+	result := &ports.PaginatedResult[*domain.BrewRecord]{}
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM brew_records WHERE brew_id = ?`,
+		brewID,
+	).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("count brew records for brew %s: %w", brewID, err)
+	}
+
+	query := `SELECT ` + brewRecordColumns + ` FROM brew_records WHERE brew_id = ?`
+	args := []any{brewID}
+	if pointer != nil {
+		var lastCreatedAt int64
+		err := conn(ctx, r.db).QueryRowContext(
+			ctx,
+			`SELECT created_at FROM brew_records WHERE id = ? AND brew_id = ?`,
+			*pointer,
+			brewID,
+		).Scan(&lastCreatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("pointer %s: %w", *pointer, domain.ErrNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolve pointer %s: %w", *pointer, err)
+		}
+
+		query += ` AND (created_at, id) > (?, ?)`
+		args = append(args, lastCreatedAt, *pointer)
+	}
+	query += ` ORDER BY created_at, id`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit+1)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list brew records for brew %s: %w", brewID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := scanBrewRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan brew record: %w", err)
+		}
+		result.Items = append(result.Items, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list brew records for brew %s: %w", brewID, err)
+	}
+
+	if limit > 0 && len(result.Items) > limit {
+		result.Items = result.Items[:limit]
+		next := result.Items[limit-1].ID
+		result.NextPointer = &next
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+func (r *BrewRecordRepository) Update(ctx context.Context, record *domain.BrewRecord) error {
+	extras, err := encodeIngredients(record.Recipe.Extras)
+	if err != nil {
+		return fmt.Errorf("encode extras for brew record %s: %w", record.ID, err)
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := requireDraftRecord(ctx, tx, record.ID); err != nil {
+			return err
+		}
+
+		recipe := record.Recipe
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE brew_records SET
+				brew_id = ?, session_id = ?,
+				water_amount = ?, water_unit = ?,
+				sugar_type = ?, sugar_amount = ?, sugar_unit = ?,
+				tea_type = ?, tea_amount = ?, tea_unit = ?,
+				extras = ?, created_at = ?, submitted_at = ?
+			WHERE id = ?`,
+			record.BrewID,
+			record.SessionID,
+			recipe.Water.Amount,
+			recipe.Water.Unit,
+			recipe.SugarType,
+			recipe.Sugar.Amount,
+			recipe.Sugar.Unit,
+			recipe.TeaType,
+			recipe.Tea.Amount,
+			recipe.Tea.Unit,
+			extras,
+			toUnix(record.CreatedAt),
+			toNullUnix(record.SubmittedAt),
+			record.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("update brew record %s: %w", record.ID, err)
+		}
+		return nil
+	})
+}
+
+func (r *BrewRecordRepository) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := requireDraftRecord(ctx, tx, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM brew_records WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("delete brew record %s: %w", id, err)
+		}
+		return nil
+	})
+}
+
+func (r *BrewRecordRepository) AppendNote(ctx context.Context, note *domain.RecordNote) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var exists bool
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT EXISTS (SELECT 1 FROM brew_records WHERE id = ?)`,
+			note.RecordID,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("check brew record %s exists: %w", note.RecordID, err)
+		}
+		if !exists {
+			return fmt.Errorf("brew record %s: %w", note.RecordID, domain.ErrNotFound)
+		}
+
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO record_notes (id, record_id, session_id, text, created_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO NOTHING`,
+			note.ID,
+			note.RecordID,
+			note.SessionID,
+			note.Text,
+			toUnix(note.CreatedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("insert record note %s: %w", note.ID, err)
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("insert record note %s: %w", note.ID, err)
+		}
+		if inserted == 0 {
+			return fmt.Errorf("record note %s: %w", note.ID, domain.ErrAlreadyExists)
+		}
+		return nil
+	})
+}
+
+func (r *BrewRecordRepository) GetNotes(ctx context.Context, recordID string) ([]*domain.RecordNote, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM brew_records WHERE id = ?)`,
+		recordID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("check brew record %s exists: %w", recordID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("brew record %s: %w", recordID, domain.ErrNotFound)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT id, record_id, session_id, text, created_at FROM record_notes
+		WHERE record_id = ? ORDER BY created_at, rowid`,
+		recordID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for brew record %s: %w", recordID, err)
+	}
+	defer rows.Close()
+
+	notes := []*domain.RecordNote{}
+	for rows.Next() {
+		var note domain.RecordNote
+		var createdAt int64
+		if err := rows.Scan(&note.ID, &note.RecordID, &note.SessionID, &note.Text, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan record note: %w", err)
+		}
+		note.CreatedAt = fromUnix(createdAt)
+		notes = append(notes, &note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list notes for brew record %s: %w", recordID, err)
+	}
+	return notes, nil
+}
+
+// requireDraftRecord guards Update and Delete: submitted records are
+// immutable, only their notes may grow.
+func requireDraftRecord(ctx context.Context, tx *sql.Tx, id string) error {
+	var submittedAt sql.NullInt64
+	err := tx.QueryRowContext(ctx, `SELECT submitted_at FROM brew_records WHERE id = ?`, id).Scan(&submittedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("brew record %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("get brew record %s: %w", id, err)
+	}
+	if submittedAt.Valid {
+		return fmt.Errorf("brew record %s: %w", id, domain.ErrImmutable)
+	}
+	return nil
+}
+
+func scanBrewRecord(row rowScanner) (*domain.BrewRecord, error) {
+	var record domain.BrewRecord
+	var extras string
+	var createdAt int64
+	var submittedAt sql.NullInt64
+
+	recipe := &record.Recipe
+	err := row.Scan(
+		&record.ID,
+		&record.BrewID,
+		&record.SessionID,
+		&recipe.Water.Amount,
+		&recipe.Water.Unit,
+		&recipe.SugarType,
+		&recipe.Sugar.Amount,
+		&recipe.Sugar.Unit,
+		&recipe.TeaType,
+		&recipe.Tea.Amount,
+		&recipe.Tea.Unit,
+		&extras,
+		&createdAt,
+		&submittedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe.Extras, err = decodeIngredients(extras)
+	if err != nil {
+		return nil, fmt.Errorf("decode extras for brew record %s: %w", record.ID, err)
+	}
+	record.CreatedAt = fromUnix(createdAt)
+	record.SubmittedAt = fromNullUnix(submittedAt)
+	return &record, nil
+}
+
+func encodeIngredients(ingredients []domain.Ingredient) (string, error) {
+	rows := make([]ingredientRow, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		rows = append(rows, ingredientRow{
+			Name:   ingredient.Name,
+			Amount: ingredient.Quantity.Amount,
+			Unit:   string(ingredient.Quantity.Unit),
+		})
+	}
+
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func decodeIngredients(data string) ([]domain.Ingredient, error) {
+	var rows []ingredientRow
+	if err := json.Unmarshal([]byte(data), &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	ingredients := make([]domain.Ingredient, 0, len(rows))
+	for _, row := range rows {
+		ingredients = append(ingredients, domain.Ingredient{
+			Name:     row.Name,
+			Quantity: domain.Quantity{Amount: row.Amount, Unit: domain.Unit(row.Unit)},
+		})
+	}
+	return ingredients, nil
+}
@@ -0,0 +1,289 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.BrewRepository = (*BrewRepository)(nil)
+
+const brewColumns = `id, name, session_id, vessel_volume_amount, vessel_volume_unit, location, color_tag,
+	scoby_origin, archived_at, next_action_at, created_at, updated_at, version`
+
+type BrewRepository struct {
+	db *sql.DB
+}
+
+func NewBrewRepository(db *sql.DB) *BrewRepository {
+	return &BrewRepository{
+		db: db,
+	}
+}
+
+func (r *BrewRepository) Save(ctx context.Context, brew *domain.Brew) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO brews (`+brewColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		brew.ID,
+		brew.Name,
+		brew.SessionID,
+		brew.VesselVolume.Amount,
+		string(brew.VesselVolume.Unit),
+		brew.Location,
+		string(brew.ColorTag),
+		brew.ScobyOrigin,
+		toNullUnix(brew.ArchivedAt),
+		toNullUnix(brew.NextActionAt),
+		toUnix(brew.CreatedAt),
+		toUnix(brew.UpdatedAt),
+		brew.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("insert brew %s: %w", brew.ID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert brew %s: %w", brew.ID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *BrewRepository) GetByID(ctx context.Context, id string) (*domain.Brew, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+brewColumns+` FROM brews WHERE id = ?`, id)
+
+	brew, err := scanBrew(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("brew %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get brew %s: %w", id, err)
+	}
+	return brew, nil
+}
+
+func (r *BrewRepository) GetBySessionID(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.Brew], error) {
+	return r.Query(ctx, domain.BrewQuery{
+		SessionID: sessionID,
+		Sort:      domain.SortByCreated,
+		Pointer:   pointer,
+		Limit:     limit,
+	})
+}
+
+func (r *BrewRepository) Query(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error) {
+	result := &ports.PaginatedResult[*domain.Brew]{}
+
+	filter := ` WHERE session_id = ?`
+	filterArgs := []any{query.SessionID}
+	switch query.Status {
+	case domain.ActiveBrewStatus:
+		filter += ` AND archived_at IS NULL`
+	case domain.ArchivedBrewStatus:
+		filter += ` AND archived_at IS NOT NULL`
+	}
+	if query.Search != "" {
+		filter += ` AND matches_search(name, ?)`
+		filterArgs = append(filterArgs, query.Search)
+	}
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM brews`+filter, filterArgs...).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("count brews for session %s: %w", query.SessionID, err)
+	}
+
+	key := brewSortKey(query.Sort, query.Descending)
+	direction, after := "ASC", ">"
+	if query.Descending {
+		direction, after = "DESC", "<"
+	}
+
+	statement := `SELECT ` + brewColumns + ` FROM brews` + filter
+	args := append([]any(nil), filterArgs...)
+	if query.Pointer != nil {
+		var lastKey int64
+		err := conn(ctx, r.db).QueryRowContext(
+			ctx,
+			`SELECT `+key+` FROM brews WHERE id = ? AND session_id = ?`,
+			*query.Pointer,
+			query.SessionID,
+		).Scan(&lastKey)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("pointer %s: %w", *query.Pointer, domain.ErrNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolve pointer %s: %w", *query.Pointer, err)
+		}
+
+		// Keyset pagination: continue strictly after the (key, id) position
+		// of the last brew the caller has already seen.
+		statement += ` AND (` + key + `, id) ` + after + ` (?, ?)`
+		args = append(args, lastKey, *query.Pointer)
+	}
+	statement += ` ORDER BY ` + key + ` ` + direction + `, id ` + direction
+	if query.Limit > 0 {
+		statement += ` LIMIT ?`
+		args = append(args, query.Limit+1)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list brews for session %s: %w", query.SessionID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		brew, err := scanBrew(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan brew: %w", err)
+		}
+		result.Items = append(result.Items, brew)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list brews for session %s: %w", query.SessionID, err)
+	}
+
+	if query.Limit > 0 && len(result.Items) > query.Limit {
+		result.Items = result.Items[:query.Limit]
+		next := result.Items[query.Limit-1].ID
+		result.NextPointer = &next
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+// brewSortKey is the SQL expression a query sorts by. Jars with nothing
+// planned go last whichever way next actions are sorted.
+func brewSortKey(by domain.BrewSort, descending bool) string {
+	switch by {
+	case domain.SortByUpdated:
+		return "updated_at"
+	case domain.SortByNextAction:
+		if descending {
+			return "COALESCE(next_action_at, -9223372036854775808)"
+		}
+		return "COALESCE(next_action_at, 9223372036854775807)"
+	default:
+		return "created_at"
+	}
+}
+
+func (r *BrewRepository) Update(ctx context.Context, brew *domain.Brew) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE brews SET name = ?, session_id = ?, vessel_volume_amount = ?, vessel_volume_unit = ?,
+			location = ?, color_tag = ?, scoby_origin = ?, archived_at = ?,
+			created_at = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`,
+		brew.Name,
+		brew.SessionID,
+		brew.VesselVolume.Amount,
+		string(brew.VesselVolume.Unit),
+		brew.Location,
+		string(brew.ColorTag),
+		brew.ScobyOrigin,
+		toNullUnix(brew.ArchivedAt),
+		toUnix(brew.CreatedAt),
+		toUnix(brew.UpdatedAt),
+		brew.ID,
+		brew.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("update brew %s: %w", brew.ID, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update brew %s: %w", brew.ID, err)
+	}
+	if updated == 0 {
+		exists, err := r.Exists(ctx, brew.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("brew %s is no longer at version %d: %w", brew.ID, brew.Version, domain.ErrConflict)
+	}
+	brew.Version++
+	return nil
+}
+
+func (r *BrewRepository) SetNextAction(ctx context.Context, id string, at *time.Time) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE brews SET next_action_at = ? WHERE id = ?`, toNullUnix(at), id)
+	if err != nil {
+		return fmt.Errorf("set next action of brew %s: %w", id, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set next action of brew %s: %w", id, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("brew %s: %w", id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *BrewRepository) Exists(ctx context.Context, id string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM brews WHERE id = ?)`, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check brew %s exists: %w", id, err)
+	}
+	return exists, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBrew(row rowScanner) (*domain.Brew, error) {
+	var brew domain.Brew
+	var vesselUnit, colorTag string
+	var archivedAt, nextActionAt sql.NullInt64
+	var createdAt, updatedAt int64
+
+	err := row.Scan(
+		&brew.ID,
+		&brew.Name,
+		&brew.SessionID,
+		&brew.VesselVolume.Amount,
+		&vesselUnit,
+		&brew.Location,
+		&colorTag,
+		&brew.ScobyOrigin,
+		&archivedAt,
+		&nextActionAt,
+		&createdAt,
+		&updatedAt,
+		&brew.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	brew.VesselVolume.Unit = domain.Unit(vesselUnit)
+	brew.ColorTag = domain.ColorTag(colorTag)
+	brew.ArchivedAt = fromNullUnix(archivedAt)
+	brew.NextActionAt = fromNullUnix(nextActionAt)
+	brew.CreatedAt = fromUnix(createdAt)
+	brew.UpdatedAt = fromUnix(updatedAt)
+	return &brew, nil
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"time"
+
+	sqlitedriver "modernc.org/sqlite"
+
+	"brew/internal/core/domain"
+	"brew/internal/utils/logger"
+)
+
+func init() {
+	// matches_search(text, search) lets queries filter with the same
+	// Unicode folding and typo tolerance as the in-memory repositories.
+	sqlitedriver.MustRegisterDeterministicScalarFunction(
+		"matches_search",
+		2,
+		func(ctx *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+			text, _ := args[0].(string)
+			search, _ := args[1].(string)
+			return domain.MatchesSearch(text, search), nil
+		},
+	)
+	// fold_search and recipe_search_text let migrations fill the full-text
+	// index the way SearchRepository.Index does.
+	sqlitedriver.MustRegisterDeterministicScalarFunction(
+		"fold_search",
+		1,
+		func(ctx *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+			text, _ := args[0].(string)
+			return domain.FoldSearchText(text), nil
+		},
+	)
+	sqlitedriver.MustRegisterDeterministicScalarFunction(
+		"recipe_search_text",
+		3,
+		func(ctx *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+			teaType, _ := args[0].(string)
+			sugarType, _ := args[1].(string)
+			extras, _ := args[2].(string)
+			ingredients, err := decodeIngredients(extras)
+			if err != nil {
+				return nil, fmt.Errorf("decode extras: %w", err)
+			}
+			recipe := domain.Recipe{
+				TeaType:   domain.TeaType(teaType),
+				SugarType: domain.SugarType(sugarType),
+				Extras:    ingredients,
+			}
+			return recipe.SearchText(), nil
+		},
+	)
+}
+
+// Open opens the SQLite database file at path and brings its schema up to date.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	logger.Debug("Opening SQLite database", "path", path)
+
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "synchronous(NORMAL)")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	// SQLite allows a single writer, so funnel every statement through one
+	// connection instead of fighting over the database lock.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	logger.Debug("SQLite database ready", "path", path)
+	return db, nil
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+
+	for i := version; i < len(migrations); i++ {
+		logger.Info("Applying database migration", "version", i+1)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin migration %d: %w", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("apply migration %d: %w", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("record migration %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction of its own, or inside a savepoint of the
+// Transactor transaction ctx carries, so that a failing fn never leaves half
+// of its writes behind in the outer transaction.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return withSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func withSavepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT nested"); err != nil {
+		return fmt.Errorf("begin savepoint: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		// ROLLBACK TO keeps the savepoint open, so it is released either way.
+		tx.ExecContext(ctx, "ROLLBACK TO nested")
+		tx.ExecContext(ctx, "RELEASE nested")
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE nested"); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
+
+func toUnix(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+func fromUnix(v int64) time.Time {
+	return time.UnixMicro(v).UTC()
+}
+
+func toNullUnix(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: toUnix(*t), Valid: true}
+}
+
+func fromNullUnix(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := fromUnix(v.Int64)
+	return &t
+}
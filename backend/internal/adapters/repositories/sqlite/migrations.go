@@ -0,0 +1,261 @@
+package sqlite
+
+// migrations are applied in order and tracked through PRAGMA user_version.
+// Never edit an entry once released; append a new one instead.
+var migrations = []string{
+	`
+	CREATE TABLE sessions (
+		id            TEXT PRIMARY KEY,
+		created_at    INTEGER NOT NULL,
+		last_accessed INTEGER NOT NULL,
+		expires_at    INTEGER,
+		is_active     INTEGER NOT NULL
+	);
+
+	CREATE TABLE share_tokens (
+		token      TEXT PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		scope      TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		expires_at INTEGER,
+		is_active  INTEGER NOT NULL
+	);
+
+	CREATE INDEX share_tokens_session_id ON share_tokens(session_id);
+
+	CREATE TABLE brews (
+		id         TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		session_id TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+
+	CREATE INDEX brews_session_id ON brews(session_id, created_at, id);
+	`,
+	`
+	CREATE TABLE brew_records (
+		id           TEXT PRIMARY KEY,
+		brew_id      TEXT NOT NULL,
+		session_id   TEXT NOT NULL,
+		water_amount REAL NOT NULL,
+		water_unit   TEXT NOT NULL,
+		sugar_type   TEXT NOT NULL,
+		sugar_amount REAL NOT NULL,
+		sugar_unit   TEXT NOT NULL,
+		tea_type     TEXT NOT NULL,
+		tea_amount   REAL NOT NULL,
+		tea_unit     TEXT NOT NULL,
+		extras       TEXT NOT NULL,
+		created_at   INTEGER NOT NULL
+	);
+
+	CREATE INDEX brew_records_brew_id ON brew_records(brew_id, created_at, id);
+	`,
+	`
+	ALTER TABLE brew_records ADD COLUMN submitted_at INTEGER;
+
+	CREATE TABLE record_notes (
+		id         TEXT PRIMARY KEY,
+		record_id  TEXT NOT NULL REFERENCES brew_records(id) ON DELETE CASCADE,
+		session_id TEXT NOT NULL,
+		text       TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	);
+
+	CREATE INDEX record_notes_record_id ON record_notes(record_id, created_at);
+	`,
+	`
+	CREATE TABLE timeline_events (
+		id           TEXT PRIMARY KEY,
+		brew_id      TEXT NOT NULL,
+		session_id   TEXT NOT NULL,
+		type         TEXT NOT NULL,
+		title        TEXT NOT NULL,
+		at           INTEGER NOT NULL,
+		completed_at INTEGER,
+		created_at   INTEGER NOT NULL
+	);
+
+	CREATE INDEX timeline_events_brew_id ON timeline_events(brew_id, at, id);
+	`,
+	`
+	CREATE TABLE quality_evaluations (
+		id          TEXT PRIMARY KEY,
+		record_id   TEXT NOT NULL,
+		brew_id     TEXT NOT NULL,
+		session_id  TEXT NOT NULL,
+		rating      INTEGER NOT NULL,
+		sweetness   INTEGER NOT NULL,
+		fizz        INTEGER NOT NULL,
+		ph          REAL,
+		notes       TEXT NOT NULL,
+		suggestions TEXT NOT NULL,
+		created_at  INTEGER NOT NULL
+	);
+
+	CREATE INDEX quality_evaluations_brew_id ON quality_evaluations(brew_id, created_at, id);
+	`,
+	`
+	CREATE TABLE audit_entries (
+		id          TEXT PRIMARY KEY,
+		session_id  TEXT NOT NULL,
+		share_token TEXT NOT NULL,
+		operation   TEXT NOT NULL,
+		brew_id     TEXT NOT NULL,
+		record_id   TEXT NOT NULL,
+		at          INTEGER NOT NULL
+	);
+
+	CREATE INDEX audit_entries_brew_id ON audit_entries(brew_id, at, id);
+	CREATE INDEX audit_entries_session_id ON audit_entries(session_id, at, id);
+	`,
+	`
+	CREATE TABLE transfer_tokens (
+		token           TEXT PRIMARY KEY,
+		brew_id         TEXT NOT NULL,
+		from_session_id TEXT NOT NULL,
+		include_records INTEGER NOT NULL,
+		created_at      INTEGER NOT NULL,
+		expires_at      INTEGER NOT NULL,
+		consumed_at     INTEGER,
+		consumed_by     TEXT NOT NULL
+	);
+	`,
+	`
+	CREATE TABLE audit_entries_seq (
+		seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+		id          TEXT NOT NULL UNIQUE,
+		session_id  TEXT NOT NULL,
+		share_token TEXT NOT NULL,
+		operation   TEXT NOT NULL,
+		brew_id     TEXT NOT NULL,
+		record_id   TEXT NOT NULL,
+		at          INTEGER NOT NULL
+	);
+
+	INSERT INTO audit_entries_seq (id, session_id, share_token, operation, brew_id, record_id, at)
+	SELECT id, session_id, share_token, operation, brew_id, record_id, at FROM audit_entries ORDER BY at, id;
+
+	DROP TABLE audit_entries;
+	ALTER TABLE audit_entries_seq RENAME TO audit_entries;
+
+	CREATE INDEX audit_entries_brew_id ON audit_entries(brew_id, at, id);
+	CREATE INDEX audit_entries_session_id ON audit_entries(session_id, at, id);
+	CREATE INDEX audit_entries_session_seq ON audit_entries(session_id, seq);
+	`,
+	`
+	CREATE TABLE sync_operations (
+		session_id   TEXT NOT NULL,
+		operation_id TEXT NOT NULL,
+		type         TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		entity_id    TEXT NOT NULL,
+		error        TEXT NOT NULL,
+		received_at  INTEGER NOT NULL,
+		PRIMARY KEY (session_id, operation_id)
+	);
+	`,
+	`
+	ALTER TABLE brews ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE sessions ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+	`,
+	`
+	ALTER TABLE brews ADD COLUMN vessel_volume_amount REAL NOT NULL DEFAULT 0;
+	ALTER TABLE brews ADD COLUMN vessel_volume_unit TEXT NOT NULL DEFAULT '';
+	ALTER TABLE brews ADD COLUMN location TEXT NOT NULL DEFAULT '';
+	ALTER TABLE brews ADD COLUMN color_tag TEXT NOT NULL DEFAULT '';
+	ALTER TABLE brews ADD COLUMN scoby_origin TEXT NOT NULL DEFAULT '';
+	ALTER TABLE brews ADD COLUMN archived_at INTEGER;
+	`,
+	`
+	ALTER TABLE brews ADD COLUMN next_action_at INTEGER;
+
+	-- Backfill what domain.ComputeNextAction returns: the earliest pending
+	-- event, else the harvest of a running batch 10 days after it started.
+	UPDATE brews SET next_action_at = COALESCE(
+		(SELECT MIN(at) FROM timeline_events WHERE brew_id = brews.id AND completed_at IS NULL),
+		(SELECT MAX(started.at) + 864000000000 FROM timeline_events started
+			WHERE started.brew_id = brews.id AND started.type IN ('start', 'refill')
+			AND NOT EXISTS (
+				SELECT 1 FROM timeline_events harvest
+				WHERE harvest.brew_id = started.brew_id
+					AND harvest.type = 'estimated_harvest'
+					AND harvest.at >= started.at
+			))
+	);
+
+	CREATE INDEX brews_session_updated_at ON brews(session_id, updated_at, id);
+	CREATE INDEX brews_session_next_action_at ON brews(session_id, next_action_at, id);
+	`,
+	`
+	-- search_documents holds what SearchRepository.Index stores; the FTS5
+	-- table indexes its folded text and is kept in step by the triggers.
+	CREATE TABLE search_documents (
+		id         INTEGER PRIMARY KEY,
+		kind       TEXT NOT NULL,
+		entity_id  TEXT NOT NULL,
+		session_id TEXT NOT NULL,
+		brew_id    TEXT NOT NULL,
+		record_id  TEXT NOT NULL,
+		text       TEXT NOT NULL,
+		folded     TEXT NOT NULL,
+		at         INTEGER NOT NULL,
+		UNIQUE (kind, entity_id)
+	);
+
+	CREATE INDEX search_documents_brew_id ON search_documents(brew_id);
+
+	CREATE VIRTUAL TABLE search_index USING fts5(
+		folded,
+		content = 'search_documents',
+		content_rowid = 'id',
+		tokenize = 'unicode61 remove_diacritics 0'
+	);
+
+	CREATE TRIGGER search_documents_insert AFTER INSERT ON search_documents BEGIN
+		INSERT INTO search_index (rowid, folded) VALUES (new.id, new.folded);
+	END;
+
+	CREATE TRIGGER search_documents_delete AFTER DELETE ON search_documents BEGIN
+		INSERT INTO search_index (search_index, rowid, folded) VALUES ('delete', old.id, old.folded);
+	END;
+
+	CREATE TRIGGER search_documents_update AFTER UPDATE OF folded ON search_documents BEGIN
+		INSERT INTO search_index (search_index, rowid, folded) VALUES ('delete', old.id, old.folded);
+		INSERT INTO search_index (rowid, folded) VALUES (new.id, new.folded);
+	END;
+
+	INSERT INTO search_documents (kind, entity_id, session_id, brew_id, record_id, text, folded, at)
+	SELECT 'brew', id, session_id, id, '', name, fold_search(name), created_at FROM brews;
+
+	INSERT INTO search_documents (kind, entity_id, session_id, brew_id, record_id, text, folded, at)
+	SELECT 'record', id, session_id, brew_id, id, text, fold_search(text), created_at
+	FROM (
+		SELECT id, session_id, brew_id, created_at,
+			recipe_search_text(tea_type, sugar_type, extras) AS text
+		FROM brew_records
+	);
+
+	INSERT INTO search_documents (kind, entity_id, session_id, brew_id, record_id, text, folded, at)
+	SELECT 'note', notes.id, records.session_id, records.brew_id, records.id,
+		notes.text, fold_search(notes.text), notes.created_at
+	FROM record_notes notes
+	JOIN brew_records records ON records.id = notes.record_id;
+	`,
+	`
+	-- Transfers always move a jar's records now; hand over what transfers
+	-- without records left behind.
+	UPDATE brew_records SET session_id = (
+		SELECT session_id FROM brews WHERE brews.id = brew_records.brew_id
+	)
+	WHERE brew_id IN (SELECT id FROM brews);
+
+	UPDATE search_documents SET session_id = (
+		SELECT session_id FROM brews WHERE brews.id = search_documents.brew_id
+	)
+	WHERE brew_id IN (SELECT id FROM brews);
+
+	ALTER TABLE transfer_tokens DROP COLUMN include_records;
+	`,
+}
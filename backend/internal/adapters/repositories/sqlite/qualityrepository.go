@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.QualityRepository = (*QualityRepository)(nil)
+
+const qualityEvaluationColumns = "id, record_id, brew_id, session_id, rating, sweetness, fizz, ph, notes, suggestions, created_at"
+
+type QualityRepository struct {
+	db *sql.DB
+}
+
+func NewQualityRepository(db *sql.DB) *QualityRepository {
+	return &QualityRepository{
+		db: db,
+	}
+}
+
+func (r *QualityRepository) Save(ctx context.Context, evaluation *domain.QualityEvaluation) error {
+	var ph sql.NullFloat64
+	if evaluation.Score.PH != nil {
+		ph = sql.NullFloat64{Float64: *evaluation.Score.PH, Valid: true}
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO quality_evaluations (`+qualityEvaluationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		evaluation.ID,
+		evaluation.RecordID,
+		evaluation.BrewID,
+		evaluation.SessionID,
+		evaluation.Score.Rating,
+		evaluation.Score.Sweetness,
+		evaluation.Score.Fizz,
+		ph,
+		evaluation.Notes,
+		evaluation.Suggestions,
+		toUnix(evaluation.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("insert quality evaluation %s: %w", evaluation.ID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert quality evaluation %s: %w", evaluation.ID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("quality evaluation %s: %w", evaluation.ID, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *QualityRepository) GetByID(ctx context.Context, id string) (*domain.QualityEvaluation, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+qualityEvaluationColumns+` FROM quality_evaluations WHERE id = ?`, id)
+
+	evaluation, err := scanQualityEvaluation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("quality evaluation %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get quality evaluation %s: %w", id, err)
+	}
+	return evaluation, nil
+}
+
+func (r *QualityRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT `+qualityEvaluationColumns+` FROM quality_evaluations WHERE brew_id = ? ORDER BY created_at, id`,
+		brewID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list quality evaluations for brew %s: %w", brewID, err)
+	}
+	defer rows.Close()
+
+	evaluations := []*domain.QualityEvaluation{}
+	for rows.Next() {
+		evaluation, err := scanQualityEvaluation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan quality evaluation: %w", err)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list quality evaluations for brew %s: %w", brewID, err)
+	}
+	return evaluations, nil
+}
+
+func scanQualityEvaluation(row rowScanner) (*domain.QualityEvaluation, error) {
+	var evaluation domain.QualityEvaluation
+	var ph sql.NullFloat64
+	var createdAt int64
+
+	err := row.Scan(
+		&evaluation.ID,
+		&evaluation.RecordID,
+		&evaluation.BrewID,
+		&evaluation.SessionID,
+		&evaluation.Score.Rating,
+		&evaluation.Score.Sweetness,
+		&evaluation.Score.Fizz,
+		&ph,
+		&evaluation.Notes,
+		&evaluation.Suggestions,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if ph.Valid {
+		evaluation.Score.PH = &ph.Float64
+	}
+	evaluation.CreatedAt = fromUnix(createdAt)
+	return &evaluation, nil
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SearchRepository = (*SearchRepository)(nil)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+func (r *SearchRepository) Index(ctx context.Context, document *domain.SearchDocument) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO search_documents (kind, entity_id, session_id, brew_id, record_id, text, folded, at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (kind, entity_id) DO UPDATE SET
+			session_id = excluded.session_id,
+			brew_id = excluded.brew_id,
+			record_id = excluded.record_id,
+			text = excluded.text,
+			folded = excluded.folded,
+			at = excluded.at`,
+		string(document.Kind),
+		document.ID,
+		document.SessionID,
+		document.BrewID,
+		document.RecordID,
+		document.Text,
+		domain.FoldSearchText(document.Text),
+		toUnix(document.At),
+	)
+	if err != nil {
+		return fmt.Errorf("index %s %s: %w", document.Kind, document.ID, err)
+	}
+	return nil
+}
+
+func (r *SearchRepository) MoveBrew(ctx context.Context, brewID string, sessionID string) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE search_documents SET session_id = ? WHERE brew_id = ?`,
+		sessionID,
+		brewID,
+	)
+	if err != nil {
+		return fmt.Errorf("move search documents of brew %s: %w", brewID, err)
+	}
+	return nil
+}
+
+func (r *SearchRepository) Search(
+	ctx context.Context,
+	sessionID string,
+	query string,
+	limit int,
+) ([]*domain.SearchHit, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	// Terms hold only letters and digits, so quoting them is enough to keep
+	// FTS5 from reading any as syntax; the star makes each a prefix.
+	phrases := make([]string, 0, len(terms))
+	for _, term := range terms {
+		phrases = append(phrases, `"`+term+`"*`)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT d.kind, d.entity_id, d.session_id, d.brew_id, d.record_id, d.text, d.at, -bm25(search_index)
+		FROM search_index
+		JOIN search_documents d ON d.id = search_index.rowid
+		WHERE search_index MATCH ? AND d.session_id = ?
+		ORDER BY bm25(search_index), d.at DESC, d.kind, d.entity_id
+		LIMIT ?`,
+		strings.Join(phrases, " "),
+		sessionID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search session %s: %w", sessionID, err)
+	}
+	defer rows.Close()
+
+	var hits []*domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		var kind string
+		var at int64
+		if err := rows.Scan(
+			&kind,
+			&hit.Document.ID,
+			&hit.Document.SessionID,
+			&hit.Document.BrewID,
+			&hit.Document.RecordID,
+			&hit.Document.Text,
+			&at,
+			&hit.Score,
+		); err != nil {
+			return nil, fmt.Errorf("scan search hit: %w", err)
+		}
+		hit.Document.Kind = domain.SearchKind(kind)
+		hit.Document.At = fromUnix(at)
+		hits = append(hits, &hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search session %s: %w", sessionID, err)
+	}
+	return hits, nil
+}
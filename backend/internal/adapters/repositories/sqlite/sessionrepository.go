@@ -0,0 +1,258 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SessionRepository = (*SessionRepository)(nil)
+
+type SessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+func (r *SessionRepository) Save(ctx context.Context, session *domain.Session) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO sessions (id, created_at, last_accessed, expires_at, is_active, version)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO NOTHING`,
+			session.ID,
+			toUnix(session.CreatedAt),
+			toUnix(session.LastAccessed),
+			toNullUnix(session.ExpiresAt),
+			session.IsActive,
+			session.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("insert session %s: %w", session.ID, err)
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("insert session %s: %w", session.ID, err)
+		}
+		if inserted == 0 {
+			return fmt.Errorf("session %s: %w", session.ID, domain.ErrAlreadyExists)
+		}
+
+		return insertShareTokens(ctx, tx, session)
+	})
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	var session domain.Session
+	var createdAt, lastAccessed int64
+	var expiresAt sql.NullInt64
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT id, created_at, last_accessed, expires_at, is_active, version FROM sessions WHERE id = ?`,
+		id,
+	).Scan(&session.ID, &createdAt, &lastAccessed, &expiresAt, &session.IsActive, &session.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get session %s: %w", id, err)
+	}
+
+	session.CreatedAt = fromUnix(createdAt)
+	session.LastAccessed = fromUnix(lastAccessed)
+	session.ExpiresAt = fromNullUnix(expiresAt)
+
+	session.ShareTokens, err = r.getShareTokens(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepository) GetByShareToken(ctx context.Context, token string) (*domain.Session, error) {
+	var sessionID string
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT session_id FROM share_tokens WHERE token = ?`, token).Scan(&sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("share token: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get session by share token: %w", err)
+	}
+	return r.GetByID(ctx, sessionID)
+}
+
+func (r *SessionRepository) GetExpired(ctx context.Context, now time.Time, ttl time.Duration) ([]*domain.Session, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT id FROM sessions
+		WHERE (expires_at IS NOT NULL AND expires_at <= ?) OR last_accessed < ?
+		ORDER BY id`,
+		toUnix(now),
+		toUnix(now.Add(-ttl)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list expired sessions: %w", err)
+	}
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan expired session: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list expired sessions: %w", err)
+	}
+
+	// The IDs are collected first: the pool holds a single connection, so
+	// loading share tokens while the rows are open would block.
+	sessions := make([]*domain.Session, 0, len(ids))
+	for _, id := range ids {
+		session, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE sessions SET created_at = ?, last_accessed = ?, expires_at = ?, is_active = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			toUnix(session.CreatedAt),
+			toUnix(session.LastAccessed),
+			toNullUnix(session.ExpiresAt),
+			session.IsActive,
+			session.ID,
+			session.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("update session %s: %w", session.ID, err)
+		}
+
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("update session %s: %w", session.ID, err)
+		}
+		if updated == 0 {
+			var exists bool
+			err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM sessions WHERE id = ?)`, session.ID).Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("check session %s exists: %w", session.ID, err)
+			}
+			if !exists {
+				return fmt.Errorf("session %s: %w", session.ID, domain.ErrNotFound)
+			}
+			return fmt.Errorf("session %s is no longer at version %d: %w", session.ID, session.Version, domain.ErrConflict)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM share_tokens WHERE session_id = ?`, session.ID); err != nil {
+			return fmt.Errorf("replace share tokens for session %s: %w", session.ID, err)
+		}
+		return insertShareTokens(ctx, tx, session)
+	})
+	if err != nil {
+		return err
+	}
+	session.Version++
+	return nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE sessions SET last_accessed = ? WHERE id = ?`, toUnix(at), id)
+	if err != nil {
+		return fmt.Errorf("touch session %s: %w", id, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("touch session %s: %w", id, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM share_tokens WHERE session_id = ?`, id); err != nil {
+			return fmt.Errorf("delete share tokens for session %s: %w", id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("delete session %s: %w", id, err)
+		}
+		return nil
+	})
+}
+
+func (r *SessionRepository) getShareTokens(ctx context.Context, sessionID string) ([]domain.ShareToken, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT token, scope, created_at, expires_at, is_active FROM share_tokens
+		WHERE session_id = ? ORDER BY created_at, token`,
+		sessionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list share tokens for session %s: %w", sessionID, err)
+	}
+	defer rows.Close()
+
+	var tokens []domain.ShareToken
+	for rows.Next() {
+		var token domain.ShareToken
+		var createdAt int64
+		var expiresAt sql.NullInt64
+
+		if err := rows.Scan(&token.Token, &token.Scope, &createdAt, &expiresAt, &token.IsActive); err != nil {
+			return nil, fmt.Errorf("scan share token: %w", err)
+		}
+		token.CreatedAt = fromUnix(createdAt)
+		token.ExpiresAt = fromNullUnix(expiresAt)
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list share tokens for session %s: %w", sessionID, err)
+	}
+	return tokens, nil
+}
+
+func insertShareTokens(ctx context.Context, tx *sql.Tx, session *domain.Session) error {
+	for _, token := range session.ShareTokens {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO share_tokens (token, session_id, scope, created_at, expires_at, is_active)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			token.Token,
+			session.ID,
+			token.Scope,
+			toUnix(token.CreatedAt),
+			toNullUnix(token.ExpiresAt),
+			token.IsActive,
+		)
+		if err != nil {
+			return fmt.Errorf("insert share token for session %s: %w", session.ID, err)
+		}
+	}
+	return nil
+}
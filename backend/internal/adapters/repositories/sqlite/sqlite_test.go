@@ -0,0 +1,239 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"brew/internal/adapters/repositories/repositorytest"
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+func newTestDB(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "brew.db")
+	db, err := Open(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, path
+}
+
+func TestBrewRepository_Contract(t *testing.T) {
+	repositorytest.TestBrewRepository(t, func(t *testing.T) ports.BrewRepository {
+		db, _ := newTestDB(t)
+		return NewBrewRepository(db)
+	})
+}
+
+func TestBrewRecordRepository_Contract(t *testing.T) {
+	repositorytest.TestBrewRecordRepository(t, func(t *testing.T) ports.BrewRecordRepository {
+		db, _ := newTestDB(t)
+		return NewBrewRecordRepository(db)
+	})
+}
+
+func TestTimelineRepository_Contract(t *testing.T) {
+	repositorytest.TestTimelineRepository(t, func(t *testing.T) ports.TimelineRepository {
+		db, _ := newTestDB(t)
+		return NewTimelineRepository(db)
+	})
+}
+
+func TestQualityRepository_Contract(t *testing.T) {
+	repositorytest.TestQualityRepository(t, func(t *testing.T) ports.QualityRepository {
+		db, _ := newTestDB(t)
+		return NewQualityRepository(db)
+	})
+}
+
+func TestAuditRepository_Contract(t *testing.T) {
+	repositorytest.TestAuditRepository(t, func(t *testing.T) ports.AuditRepository {
+		db, _ := newTestDB(t)
+		return NewAuditRepository(db)
+	})
+}
+
+func TestTransferRepository_Contract(t *testing.T) {
+	repositorytest.TestTransferRepository(t, func(t *testing.T) repositorytest.TransferRepositories {
+		db, _ := newTestDB(t)
+		return repositorytest.TransferRepositories{
+			Transfers: NewTransferRepository(db),
+			Brews:     NewBrewRepository(db),
+			Records:   NewBrewRecordRepository(db),
+		}
+	})
+}
+
+func TestSyncRepository_Contract(t *testing.T) {
+	repositorytest.TestSyncRepository(t, func(t *testing.T) ports.SyncRepository {
+		db, _ := newTestDB(t)
+		return NewSyncRepository(db)
+	})
+}
+
+func TestSearchRepository_Contract(t *testing.T) {
+	repositorytest.TestSearchRepository(t, func(t *testing.T) ports.SearchRepository {
+		db, _ := newTestDB(t)
+		return NewSearchRepository(db)
+	})
+}
+
+func TestSessionRepository_Contract(t *testing.T) {
+	repositorytest.TestSessionRepository(t, func(t *testing.T) ports.SessionRepository {
+		db, _ := newTestDB(t)
+		return NewSessionRepository(db)
+	})
+}
+
+func TestTransactor_Contract(t *testing.T) {
+	repositorytest.TestTransactor(t, func(t *testing.T) repositorytest.TransactorRepositories {
+		db, _ := newTestDB(t)
+		return repositorytest.TransactorRepositories{
+			Transactor: NewTransactor(db),
+			Brews:      NewBrewRepository(db),
+			Audit:      NewAuditRepository(db),
+			Syncs:      NewSyncRepository(db),
+		}
+	})
+}
+
+func TestOpen_SurvivesRestart(t *testing.T) {
+	db, path := newTestDB(t)
+	ctx := context.Background()
+
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	brew := &domain.Brew{ID: "brew-1", Name: "Скубі", SessionID: "session-1", CreatedAt: now, UpdatedAt: now}
+	if err := NewBrewRepository(db).Save(ctx, brew); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	db.Close()
+
+	reopened, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := NewBrewRepository(reopened).GetByID(ctx, "brew-1")
+	if err != nil {
+		t.Fatalf("GetByID() after reopen error = %v", err)
+	}
+	if got.Name != "Скубі" || !got.CreatedAt.Equal(now) {
+		t.Fatalf("GetByID() after reopen = %+v", got)
+	}
+
+	var version int
+	if err := reopened.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("read user_version error = %v", err)
+	}
+	if version != len(migrations) {
+		t.Fatalf("user_version = %d, want %d", version, len(migrations))
+	}
+}
+
+func TestOpen_IndexesExistingDataForSearch(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "brew.db")
+
+	// A database from before the search index, holding a jar, a record and
+	// a note.
+	legacy, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	const searchMigration = 12
+	statements := append(migrations[:searchMigration:searchMigration],
+		fmt.Sprintf("PRAGMA user_version = %d", searchMigration),
+		`INSERT INTO brews (id, name, session_id, created_at, updated_at) VALUES ('brew-1', 'Скубі', 'session-1', 0, 0)`,
+		`INSERT INTO brew_records (id, brew_id, session_id, water_amount, water_unit, sugar_type, sugar_amount, sugar_unit,
+			tea_type, tea_amount, tea_unit, extras, created_at)
+		VALUES ('record-1', 'brew-1', 'session-1', 3, 'l', 'cane', 0, '', 'green', 0, '',
+			'[{"name":"Hibiscus","amount":10,"unit":"g"}]', 0)`,
+		`INSERT INTO record_notes (id, record_id, session_id, text, created_at) VALUES ('note-1', 'record-1', 'session-1', 'Дуже кисло', 0)`,
+	)
+	for _, statement := range statements {
+		if _, err := legacy.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("prepare legacy database error = %v", err)
+		}
+	}
+	legacy.Close()
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSearchRepository(db)
+	for query, want := range map[string]string{
+		"скубі":    "brew-1",
+		"hibiscus": "record-1",
+		"cane":     "record-1",
+		"кисло":    "note-1",
+	} {
+		hits, err := repo.Search(ctx, "session-1", query, 10)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", query, err)
+		}
+		if len(hits) != 1 || hits[0].Document.ID != want || hits[0].Document.BrewID != "brew-1" {
+			t.Errorf("Search(%q) = %+v, want %s", query, hits, want)
+		}
+	}
+}
+
+func TestOpen_HandsOverRecordsLeftBehindByTransfers(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "brew.db")
+
+	// A jar transferred to session-2 without its records, back when
+	// transfers could leave them behind.
+	legacy, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	const handoverMigration = 13
+	statements := append(migrations[:handoverMigration:handoverMigration],
+		fmt.Sprintf("PRAGMA user_version = %d", handoverMigration),
+		`INSERT INTO brews (id, name, session_id, created_at, updated_at) VALUES ('brew-1', 'Скубі', 'session-2', 0, 0)`,
+		`INSERT INTO brew_records (id, brew_id, session_id, water_amount, water_unit, sugar_type, sugar_amount, sugar_unit,
+			tea_type, tea_amount, tea_unit, extras, created_at)
+		VALUES ('record-1', 'brew-1', 'session-1', 3, 'l', 'cane', 0, '', 'green', 0, '', '[]', 0)`,
+		`INSERT INTO search_documents (kind, entity_id, session_id, brew_id, record_id, text, folded, at)
+		VALUES ('record', 'record-1', 'session-1', 'brew-1', 'record-1', 'green tea', fold_search('green tea'), 0)`,
+		`INSERT INTO transfer_tokens (token, brew_id, from_session_id, include_records, created_at, expires_at, consumed_by)
+		VALUES ('token-1', 'brew-1', 'session-1', 0, 0, 0, 'session-2')`,
+	)
+	for _, statement := range statements {
+		if _, err := legacy.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("prepare legacy database error = %v", err)
+		}
+	}
+	legacy.Close()
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	record, err := NewBrewRecordRepository(db).GetByID(ctx, "record-1")
+	if err != nil || record.SessionID != "session-2" {
+		t.Fatalf("GetByID() record = %+v, %v, want it owned by session-2", record, err)
+	}
+	for sessionID, want := range map[string]int{"session-1": 0, "session-2": 1} {
+		hits, err := NewSearchRepository(db).Search(ctx, sessionID, "green", 10)
+		if err != nil || len(hits) != want {
+			t.Errorf("Search(%s) = %d hits, %v, want %d", sessionID, len(hits), err, want)
+		}
+	}
+	if _, err := NewTransferRepository(db).GetByToken(ctx, "token-1"); err != nil {
+		t.Errorf("GetByToken() error = %v, want the token kept", err)
+	}
+}
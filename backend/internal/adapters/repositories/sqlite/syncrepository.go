@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SyncRepository = (*SyncRepository)(nil)
+
+const syncOperationColumns = "session_id, operation_id, type, status, entity_id, error, received_at"
+
+type SyncRepository struct {
+	db *sql.DB
+}
+
+func NewSyncRepository(db *sql.DB) *SyncRepository {
+	return &SyncRepository{
+		db: db,
+	}
+}
+
+func (r *SyncRepository) Claim(
+	ctx context.Context,
+	result *domain.SyncResult,
+	staleBefore time.Time,
+) (*domain.SyncResult, bool, error) {
+	// The upsert only overwrites a pending row left behind by an applier
+	// that never finished, so two concurrent claims cannot both win.
+	inserted, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO sync_operations (`+syncOperationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (session_id, operation_id) DO UPDATE SET
+			type = excluded.type,
+			status = excluded.status,
+			entity_id = excluded.entity_id,
+			error = excluded.error,
+			received_at = excluded.received_at
+		WHERE sync_operations.status = ? AND sync_operations.received_at < ?`,
+		result.SessionID,
+		result.OperationID,
+		string(result.Type),
+		string(result.Status),
+		result.EntityID,
+		result.Error,
+		toUnix(result.ReceivedAt),
+		string(domain.SyncPending),
+		toUnix(staleBefore),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("claim sync operation %s: %w", result.OperationID, err)
+	}
+
+	claimed, err := inserted.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("claim sync operation %s: %w", result.OperationID, err)
+	}
+	if claimed > 0 {
+		clone := *result
+		return &clone, true, nil
+	}
+
+	stored, err := r.Get(ctx, result.SessionID, result.OperationID)
+	if err != nil {
+		return nil, false, err
+	}
+	return stored, false, nil
+}
+
+func (r *SyncRepository) Complete(ctx context.Context, result *domain.SyncResult) error {
+	updated, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE sync_operations SET type = ?, status = ?, entity_id = ?, error = ?, received_at = ?
+		WHERE session_id = ? AND operation_id = ?`,
+		string(result.Type),
+		string(result.Status),
+		result.EntityID,
+		result.Error,
+		toUnix(result.ReceivedAt),
+		result.SessionID,
+		result.OperationID,
+	)
+	if err != nil {
+		return fmt.Errorf("complete sync operation %s: %w", result.OperationID, err)
+	}
+
+	rows, err := updated.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("complete sync operation %s: %w", result.OperationID, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("sync operation %s: %w", result.OperationID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SyncRepository) Release(ctx context.Context, sessionID string, operationID string) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`DELETE FROM sync_operations WHERE session_id = ? AND operation_id = ? AND status = ?`,
+		sessionID,
+		operationID,
+		string(domain.SyncPending),
+	)
+	if err != nil {
+		return fmt.Errorf("release sync operation %s: %w", operationID, err)
+	}
+	return nil
+}
+
+func (r *SyncRepository) Get(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT `+syncOperationColumns+` FROM sync_operations WHERE session_id = ? AND operation_id = ?`,
+		sessionID,
+		operationID,
+	)
+
+	result, err := scanSyncResult(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("sync operation %s: %w", operationID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get sync operation %s: %w", operationID, err)
+	}
+	return result, nil
+}
+
+func scanSyncResult(row rowScanner) (*domain.SyncResult, error) {
+	var result domain.SyncResult
+	var operationType, status string
+	var receivedAt int64
+
+	err := row.Scan(
+		&result.SessionID,
+		&result.OperationID,
+		&operationType,
+		&status,
+		&result.EntityID,
+		&result.Error,
+		&receivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Type = domain.SyncOperationType(operationType)
+	result.Status = domain.SyncStatus(status)
+	result.ReceivedAt = fromUnix(receivedAt)
+	return &result, nil
+}
@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TimelineRepository = (*TimelineRepository)(nil)
+
+const timelineEventColumns = "id, brew_id, session_id, type, title, at, completed_at, created_at"
+
+type TimelineRepository struct {
+	db *sql.DB
+}
+
+func NewTimelineRepository(db *sql.DB) *TimelineRepository {
+	return &TimelineRepository{
+		db: db,
+	}
+}
+
+func (r *TimelineRepository) Save(ctx context.Context, event *domain.TimelineEvent) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO timeline_events (`+timelineEventColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		event.ID,
+		event.BrewID,
+		event.SessionID,
+		event.Type,
+		event.Title,
+		toUnix(event.At),
+		toNullUnix(event.CompletedAt),
+		toUnix(event.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("insert timeline event %s: %w", event.ID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert timeline event %s: %w", event.ID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("timeline event %s: %w", event.ID, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *TimelineRepository) GetByID(ctx context.Context, id string) (*domain.TimelineEvent, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+timelineEventColumns+` FROM timeline_events WHERE id = ?`, id)
+
+	event, err := scanTimelineEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("timeline event %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get timeline event %s: %w", id, err)
+	}
+	return event, nil
+}
+
+func (r *TimelineRepository) Update(ctx context.Context, event *domain.TimelineEvent) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE timeline_events SET
+			brew_id = ?, session_id = ?, type = ?, title = ?, at = ?, completed_at = ?, created_at = ?
+		WHERE id = ?`,
+		event.BrewID,
+		event.SessionID,
+		event.Type,
+		event.Title,
+		toUnix(event.At),
+		toNullUnix(event.CompletedAt),
+		toUnix(event.CreatedAt),
+		event.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update timeline event %s: %w", event.ID, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update timeline event %s: %w", event.ID, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("timeline event %s: %w", event.ID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *TimelineRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT `+timelineEventColumns+` FROM timeline_events WHERE brew_id = ? ORDER BY at, id`,
+		brewID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list timeline events for brew %s: %w", brewID, err)
+	}
+	defer rows.Close()
+
+	events := []*domain.TimelineEvent{}
+	for rows.Next() {
+		event, err := scanTimelineEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan timeline event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list timeline events for brew %s: %w", brewID, err)
+	}
+	return events, nil
+}
+
+func scanTimelineEvent(row rowScanner) (*domain.TimelineEvent, error) {
+	var event domain.TimelineEvent
+	var at, createdAt int64
+	var completedAt sql.NullInt64
+
+	err := row.Scan(
+		&event.ID,
+		&event.BrewID,
+		&event.SessionID,
+		&event.Type,
+		&event.Title,
+		&at,
+		&completedAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.At = fromUnix(at)
+	event.CompletedAt = fromNullUnix(completedAt)
+	event.CreatedAt = fromUnix(createdAt)
+	return &event, nil
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TransferRepository = (*TransferRepository)(nil)
+
+const transferTokenColumns = "token, brew_id, from_session_id, created_at, expires_at, consumed_at, consumed_by"
+
+type TransferRepository struct {
+	db *sql.DB
+}
+
+func NewTransferRepository(db *sql.DB) *TransferRepository {
+	return &TransferRepository{
+		db: db,
+	}
+}
+
+func (r *TransferRepository) Save(ctx context.Context, token *domain.TransferToken) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO transfer_tokens (`+transferTokenColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (token) DO NOTHING`,
+		token.Token,
+		token.BrewID,
+		token.FromSessionID,
+		toUnix(token.CreatedAt),
+		toUnix(token.ExpiresAt),
+		toNullUnix(token.ConsumedAt),
+		token.ConsumedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("insert transfer token for brew %s: %w", token.BrewID, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert transfer token for brew %s: %w", token.BrewID, err)
+	}
+	if inserted == 0 {
+		return fmt.Errorf("transfer token: %w", domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *TransferRepository) GetByToken(ctx context.Context, token string) (*domain.TransferToken, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+transferTokenColumns+` FROM transfer_tokens WHERE token = ?`, token)
+
+	transfer, err := scanTransferToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("transfer token: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get transfer token: %w", err)
+	}
+	return transfer, nil
+}
+
+func (r *TransferRepository) Redeem(
+	ctx context.Context,
+	token string,
+	toSessionID string,
+	now time.Time,
+) (*domain.TransferToken, error) {
+	var transfer *domain.TransferToken
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `SELECT `+transferTokenColumns+` FROM transfer_tokens WHERE token = ?`, token)
+
+		var err error
+		transfer, err = scanTransferToken(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("transfer token: %w", domain.ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("get transfer token: %w", err)
+		}
+		if !transfer.IsUsable(now) {
+			return fmt.Errorf("transfer token is used or expired: %w", domain.ErrForbidden)
+		}
+
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE brews SET session_id = ?, updated_at = ?, version = version + 1 WHERE id = ? AND session_id = ?`,
+			toSessionID,
+			toUnix(now),
+			transfer.BrewID,
+			transfer.FromSessionID,
+		)
+		if err != nil {
+			return fmt.Errorf("transfer brew %s: %w", transfer.BrewID, err)
+		}
+		moved, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("transfer brew %s: %w", transfer.BrewID, err)
+		}
+		if moved == 0 {
+			return fmt.Errorf("brew %s changed owner since the transfer was offered: %w", transfer.BrewID, domain.ErrForbidden)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE brew_records SET session_id = ? WHERE brew_id = ?`,
+			toSessionID,
+			transfer.BrewID,
+		)
+		if err != nil {
+			return fmt.Errorf("transfer records of brew %s: %w", transfer.BrewID, err)
+		}
+
+		consumedAt := now
+		transfer.ConsumedAt = &consumedAt
+		transfer.ConsumedBy = toSessionID
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE transfer_tokens SET consumed_at = ?, consumed_by = ? WHERE token = ?`,
+			toUnix(now),
+			toSessionID,
+			token,
+		)
+		if err != nil {
+			return fmt.Errorf("consume transfer token for brew %s: %w", transfer.BrewID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func scanTransferToken(row rowScanner) (*domain.TransferToken, error) {
+	var transfer domain.TransferToken
+	var createdAt, expiresAt int64
+	var consumedAt sql.NullInt64
+
+	err := row.Scan(
+		&transfer.Token,
+		&transfer.BrewID,
+		&transfer.FromSessionID,
+		&createdAt,
+		&expiresAt,
+		&consumedAt,
+		&transfer.ConsumedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.CreatedAt = fromUnix(createdAt)
+	transfer.ExpiresAt = fromUnix(expiresAt)
+	transfer.ConsumedAt = fromNullUnix(consumedAt)
+	return &transfer, nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type AuditOperation string
+
+const (
+	AuditSessionCreated     AuditOperation = "session.created"
+	AuditSessionUpdated     AuditOperation = "session.updated"
+	AuditSessionDeleted     AuditOperation = "session.deleted"
+	AuditSessionExpired     AuditOperation = "session.expired"
+	AuditShareTokenCreated  AuditOperation = "share_token.created"
+	AuditShareTokenRevoked  AuditOperation = "share_token.revoked"
+	AuditBrewCreated        AuditOperation = "brew.created"
+	AuditBrewRenamed        AuditOperation = "brew.renamed"
+	AuditBrewUpdated        AuditOperation = "brew.updated"
+	AuditBrewArchived       AuditOperation = "brew.archived"
+	AuditBrewUnarchived     AuditOperation = "brew.unarchived"
+	AuditTransferOffered    AuditOperation = "brew.transfer_offered"
+	AuditBrewTransferredIn  AuditOperation = "brew.transferred_in"
+	AuditBrewTransferredOut AuditOperation = "brew.transferred_out"
+	AuditRecordAdded        AuditOperation = "record.added"
+	AuditNoteAppended       AuditOperation = "record.note_appended"
+	AuditTimelineAdded      AuditOperation = "timeline.added"
+	AuditTimelineCompleted  AuditOperation = "timeline.completed"
+	AuditEvaluationAdded    AuditOperation = "evaluation.added"
+)
+
+// AuditEntry records who changed what. ShareToken is empty when the session
+// owner made the edit; BrewID and RecordID are empty when the operation does
+// not touch a jar.
+type AuditEntry struct {
+	// Seq is assigned by the repository and grows with every entry written,
+	// which makes it usable as a sync cursor.
+	Seq        int64
+	ID         string
+	SessionID  string
+	ShareToken string
+	Operation  AuditOperation
+	BrewID     string
+	RecordID   string
+	At         time.Time
+}
+
+// IsShared reports whether the edit was made through a share token.
+func (e *AuditEntry) IsShared() bool {
+	return e.ShareToken != ""
+}
+
+// Redact drops who made the edit. Session IDs and share tokens are
+// credentials, so they are only shown to the session they belong to.
+func (e *AuditEntry) Redact() {
+	e.SessionID = ""
+	e.ShareToken = ""
+}
@@ -4,24 +4,191 @@ import (
 	"time"
 )
 
+// ColorTag is the colour of the sticker or lid that tells jars apart at a
+// glance on the shelf.
+type ColorTag string
+
+const (
+	RedTag    ColorTag = "red"
+	OrangeTag ColorTag = "orange"
+	YellowTag ColorTag = "yellow"
+	GreenTag  ColorTag = "green"
+	BlueTag   ColorTag = "blue"
+	PurpleTag ColorTag = "purple"
+	PinkTag   ColorTag = "pink"
+	BrownTag  ColorTag = "brown"
+	GrayTag   ColorTag = "gray"
+)
+
+func (t ColorTag) IsKnown() bool {
+	switch t {
+	case RedTag, OrangeTag, YellowTag, GreenTag, BlueTag, PurpleTag, PinkTag, BrownTag, GrayTag:
+		return true
+	default:
+		return false
+	}
+}
+
 type Brew struct {
 	ID        string
 	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	SessionID string
+	// VesselVolume is the capacity of the jar itself, zero when not measured.
+	VesselVolume Quantity
+	Location     string
+	ColorTag     ColorTag
+	// ScobyOrigin says where the culture came from, e.g. "from Olena's jar".
+	ScobyOrigin string
+	// ArchivedAt is set while the jar is retired; its history stays readable.
+	ArchivedAt *time.Time
+	// NextActionAt is when the jar's next timeline action is due, nil when
+	// nothing is planned. Only the timeline keeps it, for sorting lists.
+	NextActionAt *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// Version is bumped by every successful repository Update and guards
+	// against two edits of the same jar overwriting each other.
+	Version int64
 }
 
-func NewBrew(id string, name string) *Brew {
-	now := time.Now()
+// NewBrew starts a jar owned by sessionID, created and last updated at now.
+func NewBrew(id string, name string, sessionID string, now time.Time) *Brew {
 	return &Brew{
 		ID:        id,
 		Name:      name,
+		SessionID: sessionID,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
-func (b *Brew) UpdateName(name string) {
+func (b *Brew) UpdateName(name string, now time.Time) {
 	b.Name = name
-	b.UpdatedAt = time.Now()
+	b.UpdatedAt = now
+}
+
+// Apply sets every field the changes carry and leaves the others alone.
+func (b *Brew) Apply(changes BrewChanges, now time.Time) {
+	if changes.Name != nil {
+		b.Name = *changes.Name
+	}
+	if changes.VesselVolume != nil {
+		b.VesselVolume = *changes.VesselVolume
+	}
+	if changes.Location != nil {
+		b.Location = *changes.Location
+	}
+	if changes.ColorTag != nil {
+		b.ColorTag = *changes.ColorTag
+	}
+	if changes.ScobyOrigin != nil {
+		b.ScobyOrigin = *changes.ScobyOrigin
+	}
+	b.UpdatedAt = now
+}
+
+func (b *Brew) IsArchived() bool {
+	return b.ArchivedAt != nil
+}
+
+// Archive retires the jar and reports whether it was active before.
+func (b *Brew) Archive(now time.Time) bool {
+	if b.IsArchived() {
+		return false
+	}
+	b.ArchivedAt = &now
+	b.UpdatedAt = now
+	return true
+}
+
+// Unarchive brings the jar back and reports whether it was archived before.
+func (b *Brew) Unarchive(now time.Time) bool {
+	if !b.IsArchived() {
+		return false
+	}
+	b.ArchivedAt = nil
+	b.UpdatedAt = now
+	return true
+}
+
+// BrewChanges is a partial edit of a jar's details: nil fields are kept, and
+// an empty value clears an optional field.
+type BrewChanges struct {
+	Name         *string
+	VesselVolume *Quantity
+	Location     *string
+	ColorTag     *ColorTag
+	ScobyOrigin  *string
+}
+
+func (c BrewChanges) IsEmpty() bool {
+	return c.Name == nil && c.VesselVolume == nil && c.Location == nil && c.ColorTag == nil && c.ScobyOrigin == nil
+}
+
+// BrewStatus filters jar lists; the empty status lists every jar.
+type BrewStatus string
+
+const (
+	AnyBrewStatus      BrewStatus = ""
+	ActiveBrewStatus   BrewStatus = "active"
+	ArchivedBrewStatus BrewStatus = "archived"
+)
+
+func (s BrewStatus) IsKnown() bool {
+	switch s {
+	case AnyBrewStatus, ActiveBrewStatus, ArchivedBrewStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// Matches reports whether brew belongs in a list filtered by s.
+func (s BrewStatus) Matches(brew *Brew) bool {
+	switch s {
+	case ActiveBrewStatus:
+		return !brew.IsArchived()
+	case ArchivedBrewStatus:
+		return brew.IsArchived()
+	default:
+		return true
+	}
+}
+
+type BrewSort string
+
+const (
+	SortByCreated    BrewSort = "created"
+	SortByUpdated    BrewSort = "updated"
+	SortByNextAction BrewSort = "next_action"
+)
+
+func (s BrewSort) IsKnown() bool {
+	switch s {
+	case SortByCreated, SortByUpdated, SortByNextAction:
+		return true
+	default:
+		return false
+	}
+}
+
+// BrewQuery selects a page of a session's jars. Ties are broken by ID, and
+// jars with nothing planned come last when sorting by next action, in
+// either direction. Pointer is the ID of the last jar of the previous page.
+type BrewQuery struct {
+	SessionID  string
+	Search     string
+	Status     BrewStatus
+	Sort       BrewSort
+	Descending bool
+	Pointer    *string
+	Limit      int
+}
+
+// BrewDetails is everything shown after scanning a jar: the jar itself, its
+// latest batch with notes and the full record history, oldest first.
+type BrewDetails struct {
+	Brew         *Brew
+	LatestRecord *AnnotatedRecord
+	History      []*BrewRecord
 }
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// The error catalogue. Repositories and services wrap one of these with
+// context, e.g. fmt.Errorf("brew %s: %w", id, ErrNotFound), so callers can
+// branch with errors.Is whatever the message says.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrForbidden       = errors.New("forbidden")
+	ErrSessionInactive = errors.New("session is inactive")
+	ErrImmutable       = errors.New("immutable once submitted")
+	ErrInvalid         = errors.New("invalid input")
+	// ErrConflict means the entity changed since the caller read it, i.e.
+	// the Version it presented is stale.
+	ErrConflict = errors.New("modified concurrently")
+)
+
+// FieldError names an input field and what is wrong with it, in words fit
+// to show next to the field in a form.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is ErrInvalid with the offending fields attached.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// InvalidField reports a single bad field.
+func InvalidField(field string, message string) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+func (e *ValidationError) Add(field string, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// Err returns e when it holds any field errors and nil otherwise, so a
+// validator can collect everything before deciding.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		parts = append(parts, field.Field+" "+field.Message)
+	}
+	return strings.Join(parts, "; ") + ": " + ErrInvalid.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalid
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LabelPreset describes a sheet of sticky labels. All lengths are in
+// millimetres and labels are laid out left to right, top to bottom.
+type LabelPreset struct {
+	Name        string
+	Description string
+	PageWidth   float64
+	PageHeight  float64
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	MarginTop   float64
+	MarginLeft  float64
+	GapX        float64
+	GapY        float64
+}
+
+func (p LabelPreset) Capacity() int {
+	return p.Columns * p.Rows
+}
+
+// Position returns the top-left corner of the label in the given slot.
+func (p LabelPreset) Position(slot int) (x float64, y float64) {
+	column := slot % p.Columns
+	row := slot / p.Columns
+	x = p.MarginLeft + float64(column)*(p.LabelWidth+p.GapX)
+	y = p.MarginTop + float64(row)*(p.LabelHeight+p.GapY)
+	return x, y
+}
+
+const DefaultLabelPreset = "avery-l7160"
+
+var labelPresets = map[string]LabelPreset{
+	"avery-l7160": {
+		Description: "A4, 21 labels 63.5 x 38.1 mm",
+		PageWidth:   210, PageHeight: 297,
+		Columns: 3, Rows: 7,
+		LabelWidth: 63.5, LabelHeight: 38.1,
+		MarginTop: 15.15, MarginLeft: 7.25,
+		GapX: 2.5,
+	},
+	"avery-l7163": {
+		Description: "A4, 14 labels 99.1 x 38.1 mm",
+		PageWidth:   210, PageHeight: 297,
+		Columns: 2, Rows: 7,
+		LabelWidth: 99.1, LabelHeight: 38.1,
+		MarginTop: 15.15, MarginLeft: 4.65,
+		GapX: 2.5,
+	},
+	"avery-l7165": {
+		Description: "A4, 8 labels 99.1 x 67.7 mm",
+		PageWidth:   210, PageHeight: 297,
+		Columns: 2, Rows: 4,
+		LabelWidth: 99.1, LabelHeight: 67.7,
+		MarginTop: 13.1, MarginLeft: 4.65,
+		GapX: 2.5,
+	},
+	"avery-5160": {
+		Description: "US Letter, 30 labels 2.625 x 1 in",
+		PageWidth:   215.9, PageHeight: 279.4,
+		Columns: 3, Rows: 10,
+		LabelWidth: 66.675, LabelHeight: 25.4,
+		MarginTop: 12.7, MarginLeft: 4.7625,
+		GapX: 3.175,
+	},
+	"avery-5163": {
+		Description: "US Letter, 10 labels 4 x 2 in",
+		PageWidth:   215.9, PageHeight: 279.4,
+		Columns: 2, Rows: 5,
+		LabelWidth: 101.6, LabelHeight: 50.8,
+		MarginTop: 12.7, MarginLeft: 3.96875,
+		GapX: 4.7625,
+	},
+}
+
+func LabelPresetByName(name string) (LabelPreset, error) {
+	preset, ok := labelPresets[name]
+	if !ok {
+		return LabelPreset{}, fmt.Errorf("label preset %q: %w", name, ErrInvalid)
+	}
+	preset.Name = name
+	return preset, nil
+}
+
+func LabelPresets() []LabelPreset {
+	presets := make([]LabelPreset, 0, len(labelPresets))
+	for name, preset := range labelPresets {
+		preset.Name = name
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+	return presets
+}
+
+// Label is what gets printed for one jar: its QR code as a PNG, the
+// friendly name and the short ID to type in when a scan fails.
+type Label struct {
+	BrewID string
+	Name   string
+	QRCode []byte
+}
+
+// LabelSheet is a print job. Skip leaves the first slots of the first page
+// empty so a partly used sheet can go back into the printer.
+type LabelSheet struct {
+	Preset LabelPreset
+	Labels []Label
+	Skip   int
+}
+
+// PageCount is how many sheets the print job needs.
+func (s LabelSheet) PageCount() int {
+	slots := s.Skip + len(s.Labels)
+	capacity := s.Preset.Capacity()
+	return (slots + capacity - 1) / capacity
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	MinScore = 1
+	MaxScore = 5
+)
+
+// QualityScore is what a brewer measures when tasting a batch. Rating,
+// Sweetness and Fizz use the MinScore..MaxScore scale; PH is optional
+// because not everyone owns strips or a meter.
+type QualityScore struct {
+	Rating    int
+	Sweetness int
+	Fizz      int
+	PH        *float64
+}
+
+func (s QualityScore) Validate() error {
+	scales := []struct {
+		name  string
+		value int
+	}{
+		{name: "rating", value: s.Rating},
+		{name: "sweetness", value: s.Sweetness},
+		{name: "fizz", value: s.Fizz},
+	}
+	invalid := &ValidationError{}
+	for _, scale := range scales {
+		if scale.value < MinScore || scale.value > MaxScore {
+			invalid.Add(scale.name, fmt.Sprintf("must be between %d and %d", MinScore, MaxScore))
+		}
+	}
+	if s.PH != nil && (*s.PH < 0 || *s.PH > 14) {
+		invalid.Add("ph", "must be between 0 and 14")
+	}
+	return invalid.Err()
+}
+
+// QualityEvaluation is a tasting of one batch (brew record).
+type QualityEvaluation struct {
+	ID          string
+	RecordID    string
+	BrewID      string
+	SessionID   string
+	Score       QualityScore
+	Notes       string
+	Suggestions string
+	CreatedAt   time.Time
+}
+
+func NewQualityEvaluation(
+	id string,
+	record *BrewRecord,
+	sessionID string,
+	score QualityScore,
+	notes string,
+	suggestions string,
+	now time.Time,
+) *QualityEvaluation {
+	return &QualityEvaluation{
+		ID:          id,
+		RecordID:    record.ID,
+		BrewID:      record.BrewID,
+		SessionID:   sessionID,
+		Score:       score,
+		Notes:       notes,
+		Suggestions: suggestions,
+		CreatedAt:   now,
+	}
+}
+
+type QualityMetrics struct {
+	Count            int
+	AverageRating    float64
+	AverageSweetness float64
+	AverageFizz      float64
+	// AveragePH is nil when none of the evaluations measured pH.
+	AveragePH *float64
+	// RatingTrend is the change in rating per evaluation, fitted over the
+	// evaluations in chronological order. Positive means batches improve.
+	RatingTrend float64
+	FirstAt     *time.Time
+	LastAt      *time.Time
+}
+
+// AggregateQuality summarises evaluations into averages and a rating trend.
+func AggregateQuality(evaluations []*QualityEvaluation) QualityMetrics {
+	metrics := QualityMetrics{Count: len(evaluations)}
+	if len(evaluations) == 0 {
+		return metrics
+	}
+
+	sorted := append([]*QualityEvaluation(nil), evaluations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var rating, sweetness, fizz, ph float64
+	var phCount int
+	ratings := make([]float64, 0, len(sorted))
+	for _, evaluation := range sorted {
+		rating += float64(evaluation.Score.Rating)
+		sweetness += float64(evaluation.Score.Sweetness)
+		fizz += float64(evaluation.Score.Fizz)
+		if evaluation.Score.PH != nil {
+			ph += *evaluation.Score.PH
+			phCount++
+		}
+		ratings = append(ratings, float64(evaluation.Score.Rating))
+	}
+
+	count := float64(len(sorted))
+	metrics.AverageRating = rating / count
+	metrics.AverageSweetness = sweetness / count
+	metrics.AverageFizz = fizz / count
+	if phCount > 0 {
+		average := ph / float64(phCount)
+		metrics.AveragePH = &average
+	}
+	metrics.RatingTrend = slope(ratings)
+
+	first := sorted[0].CreatedAt
+	last := sorted[len(sorted)-1].CreatedAt
+	metrics.FirstAt = &first
+	metrics.LastAt = &last
+	return metrics
+}
+
+// slope is the least-squares slope of values over their indexes.
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return math.Round((n*sumXY-sumX*sumY)/denominator*1000) / 1000
+}
+
+// RecipeQuality is the aggregated quality of every batch brewed with
+// recipes that share the same Recipe.Key.
+type RecipeQuality struct {
+	Key string
+	// Recipe is the most recently evaluated batch's recipe.
+	Recipe  Recipe
+	BrewIDs []string
+	Metrics QualityMetrics
+}
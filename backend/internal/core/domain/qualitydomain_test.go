@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQualityScore_Validate(t *testing.T) {
+	ph := 3.1
+	badPH := 15.0
+
+	tests := []struct {
+		name    string
+		score   QualityScore
+		wantErr bool
+	}{
+		{name: "valid", score: QualityScore{Rating: 4, Sweetness: 3, Fizz: 5, PH: &ph}},
+		{name: "valid without ph", score: QualityScore{Rating: 1, Sweetness: 1, Fizz: 1}},
+		{name: "rating too high", score: QualityScore{Rating: 6, Sweetness: 3, Fizz: 3}, wantErr: true},
+		{name: "fizz missing", score: QualityScore{Rating: 3, Sweetness: 3}, wantErr: true},
+		{name: "ph out of range", score: QualityScore{Rating: 3, Sweetness: 3, Fizz: 3, PH: &badPH}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.score.Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalid) {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQualityScore_ValidateReportsEveryField(t *testing.T) {
+	badPH := -1.0
+	err := QualityScore{Rating: 0, Sweetness: 3, Fizz: 9, PH: &badPH}.Validate()
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Validate() error = %v, want a ValidationError", err)
+	}
+	fields := make([]string, 0, len(invalid.Fields))
+	for _, field := range invalid.Fields {
+		fields = append(fields, field.Field)
+	}
+	if len(fields) != 3 || fields[0] != "rating" || fields[1] != "fizz" || fields[2] != "ph" {
+		t.Fatalf("Validate() fields = %v, want [rating fizz ph]", fields)
+	}
+	if (&ValidationError{}).Err() != nil {
+		t.Fatal("Err() of an empty ValidationError is not nil")
+	}
+}
+
+func TestAggregateQuality(t *testing.T) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	ph := 3.0
+
+	evaluation := func(rating int, offset time.Duration, ph *float64) *QualityEvaluation {
+		return &QualityEvaluation{
+			Score:     QualityScore{Rating: rating, Sweetness: 2, Fizz: rating, PH: ph},
+			CreatedAt: base.Add(offset),
+		}
+	}
+
+	metrics := AggregateQuality([]*QualityEvaluation{
+		evaluation(4, 2*time.Hour, nil),
+		evaluation(2, 0, &ph),
+		evaluation(3, time.Hour, nil),
+	})
+
+	if metrics.Count != 3 {
+		t.Errorf("Count = %d, want 3", metrics.Count)
+	}
+	if metrics.AverageRating != 3 || metrics.AverageSweetness != 2 || metrics.AverageFizz != 3 {
+		t.Errorf("averages = %+v", metrics)
+	}
+	if metrics.AveragePH == nil || *metrics.AveragePH != 3 {
+		t.Errorf("AveragePH = %v, want 3", metrics.AveragePH)
+	}
+	if metrics.RatingTrend != 1 {
+		t.Errorf("RatingTrend = %v, want 1", metrics.RatingTrend)
+	}
+	if !metrics.FirstAt.Equal(base) || !metrics.LastAt.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("FirstAt/LastAt = %v/%v", metrics.FirstAt, metrics.LastAt)
+	}
+
+	empty := AggregateQuality(nil)
+	if empty.Count != 0 || empty.AveragePH != nil || empty.FirstAt != nil {
+		t.Errorf("AggregateQuality(nil) = %+v", empty)
+	}
+}
+
+func TestRecipe_Key(t *testing.T) {
+	small := Recipe{
+		Water:     Quantity{Amount: 1, Unit: Liters},
+		SugarType: WhiteSugar,
+		Sugar:     Quantity{Amount: 80, Unit: Grams},
+		TeaType:   BlackTea,
+		Tea:       Quantity{Amount: 2, Unit: Pieces},
+		Extras:    []Ingredient{{Name: "Ginger"}, {Name: "lemon"}},
+	}
+	large := Recipe{
+		Water:     Quantity{Amount: 3000, Unit: Milliliters},
+		SugarType: WhiteSugar,
+		Sugar:     Quantity{Amount: 0.24, Unit: Kilograms},
+		TeaType:   BlackTea,
+		Tea:       Quantity{Amount: 6, Unit: Pieces},
+		Extras:    []Ingredient{{Name: "lemon"}, {Name: " ginger "}},
+	}
+
+	if small.Key() != large.Key() {
+		t.Fatalf("Key() differs for scaled recipe: %q vs %q", small.Key(), large.Key())
+	}
+
+	sweeter := small
+	sweeter.Sugar = Quantity{Amount: 100, Unit: Grams}
+	if small.Key() == sweeter.Key() {
+		t.Fatalf("Key() is equal for different sugar ratios: %q", small.Key())
+	}
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Unit string
+
+const (
+	Milliliters Unit = "ml"
+	Liters      Unit = "l"
+	Grams       Unit = "g"
+	Kilograms   Unit = "kg"
+	Teaspoons   Unit = "tsp"
+	Tablespoons Unit = "tbsp"
+	Pieces      Unit = "pcs"
+)
+
+type dimension string
+
+const (
+	volume dimension = "volume"
+	mass   dimension = "mass"
+	count  dimension = "count"
+)
+
+type unitInfo struct {
+	dimension dimension
+	// base is how many base units (ml, g, spoon or piece) one unit holds.
+	base float64
+}
+
+var units = map[Unit]unitInfo{
+	Milliliters: {dimension: volume, base: 1},
+	Liters:      {dimension: volume, base: 1000},
+	Grams:       {dimension: mass, base: 1},
+	Kilograms:   {dimension: mass, base: 1000},
+	Teaspoons:   {dimension: volume, base: 5},
+	Tablespoons: {dimension: volume, base: 15},
+	Pieces:      {dimension: count, base: 1},
+}
+
+func (u Unit) IsKnown() bool {
+	_, ok := units[u]
+	return ok
+}
+
+type Quantity struct {
+	Amount float64
+	Unit   Unit
+}
+
+// In converts the quantity to another unit of the same dimension,
+// e.g. liters of water to milliliters.
+func (q Quantity) In(unit Unit) (Quantity, error) {
+	from, ok := units[q.Unit]
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q: %w", q.Unit, ErrInvalid)
+	}
+	to, ok := units[unit]
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q: %w", unit, ErrInvalid)
+	}
+	if from.dimension != to.dimension {
+		return Quantity{}, fmt.Errorf("cannot convert %s to %s: %w", q.Unit, unit, ErrInvalid)
+	}
+	return Quantity{Amount: q.Amount * from.base / to.base, Unit: unit}, nil
+}
+
+func (q Quantity) IsZero() bool {
+	return q.Amount == 0
+}
+
+type SugarType string
+
+const (
+	WhiteSugar   SugarType = "white"
+	CaneSugar    SugarType = "cane"
+	BrownSugar   SugarType = "brown"
+	CoconutSugar SugarType = "coconut"
+	Honey        SugarType = "honey"
+	OtherSugar   SugarType = "other"
+)
+
+func (t SugarType) IsKnown() bool {
+	switch t {
+	case WhiteSugar, CaneSugar, BrownSugar, CoconutSugar, Honey, OtherSugar:
+		return true
+	default:
+		return false
+	}
+}
+
+type TeaType string
+
+const (
+	BlackTea  TeaType = "black"
+	GreenTea  TeaType = "green"
+	WhiteTea  TeaType = "white"
+	OolongTea TeaType = "oolong"
+	PuerhTea  TeaType = "pu-erh"
+	HerbalTea TeaType = "herbal"
+	MixedTea  TeaType = "mixed"
+	OtherTea  TeaType = "other"
+)
+
+func (t TeaType) IsKnown() bool {
+	switch t {
+	case BlackTea, GreenTea, WhiteTea, OolongTea, PuerhTea, HerbalTea, MixedTea, OtherTea:
+		return true
+	default:
+		return false
+	}
+}
+
+type Ingredient struct {
+	Name     string
+	Quantity Quantity
+}
+
+type Recipe struct {
+	Water     Quantity
+	SugarType SugarType
+	Sugar     Quantity
+	TeaType   TeaType
+	Tea       Quantity
+	Extras    []Ingredient
+}
+
+// Key identifies the recipe independently of the batch size: sugar and tea
+// are expressed per liter of water and extras are compared by name only,
+// so a 1 l and a 3 l batch of the same recipe share a key.
+func (r Recipe) Key() string {
+	water, err := r.Water.In(Liters)
+	if err != nil || water.Amount <= 0 {
+		water = Quantity{Amount: 1, Unit: Liters}
+	}
+
+	extras := make([]string, 0, len(r.Extras))
+	for _, extra := range r.Extras {
+		extras = append(extras, strings.ToLower(strings.TrimSpace(extra.Name)))
+	}
+	sort.Strings(extras)
+
+	return fmt.Sprintf(
+		"%s|%s|%s|%s|%s",
+		r.TeaType,
+		perLiter(r.Tea, water.Amount),
+		r.SugarType,
+		perLiter(r.Sugar, water.Amount),
+		strings.Join(extras, ","),
+	)
+}
+
+// perLiter renders a solid ingredient as whole grams per liter, falling
+// back to its own unit for things that cannot be weighed, like tea bags.
+func perLiter(q Quantity, liters float64) string {
+	if grams, err := q.In(Grams); err == nil {
+		return fmt.Sprintf("%gg/l", math.Round(grams.Amount/liters))
+	}
+	return fmt.Sprintf("%g%s/l", math.Round(q.Amount/liters*10)/10, q.Unit)
+}
+
+// BrewRecord is a single batch brewed in a jar. Once submitted it can no
+// longer be changed; corrections and comments go into RecordNotes.
+type BrewRecord struct {
+	ID          string
+	BrewID      string
+	SessionID   string
+	Recipe      Recipe
+	CreatedAt   time.Time
+	SubmittedAt *time.Time
+}
+
+func NewBrewRecord(id string, brewID string, sessionID string, recipe Recipe, now time.Time) *BrewRecord {
+	return &BrewRecord{
+		ID:        id,
+		BrewID:    brewID,
+		SessionID: sessionID,
+		Recipe:    recipe,
+		CreatedAt: now,
+	}
+}
+
+func (r *BrewRecord) Submit(now time.Time) {
+	if r.SubmittedAt != nil {
+		return
+	}
+	r.SubmittedAt = &now
+}
+
+func (r *BrewRecord) IsSubmitted() bool {
+	return r.SubmittedAt != nil
+}
+
+// RecordNote is an append-only comment attached to a brew record by any
+// session with access to the jar.
+type RecordNote struct {
+	ID        string
+	RecordID  string
+	SessionID string
+	Text      string
+	CreatedAt time.Time
+}
+
+func NewRecordNote(id string, recordID string, sessionID string, text string, now time.Time) *RecordNote {
+	return &RecordNote{
+		ID:        id,
+		RecordID:  recordID,
+		SessionID: sessionID,
+		Text:      text,
+		CreatedAt: now,
+	}
+}
+
+// AnnotatedRecord is a brew record together with its full note chain in
+// the order the notes were appended.
+type AnnotatedRecord struct {
+	Record *BrewRecord
+	Notes  []*RecordNote
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestQuantity_In(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity Quantity
+		unit     Unit
+		want     float64
+		wantErr  bool
+	}{
+		{name: "liters to milliliters", quantity: Quantity{Amount: 1.5, Unit: Liters}, unit: Milliliters, want: 1500},
+		{name: "grams to kilograms", quantity: Quantity{Amount: 250, Unit: Grams}, unit: Kilograms, want: 0.25},
+		{name: "tablespoons to teaspoons", quantity: Quantity{Amount: 2, Unit: Tablespoons}, unit: Teaspoons, want: 6},
+		{name: "same unit", quantity: Quantity{Amount: 3, Unit: Pieces}, unit: Pieces, want: 3},
+		{name: "mass to volume", quantity: Quantity{Amount: 1, Unit: Grams}, unit: Milliliters, wantErr: true},
+		{name: "unknown unit", quantity: Quantity{Amount: 1, Unit: "cup"}, unit: Milliliters, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.quantity.In(tt.unit)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("In() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("In() error = %v", err)
+			}
+			if got.Amount != tt.want || got.Unit != tt.unit {
+				t.Fatalf("In() = %+v, want %v %s", got, tt.want, tt.unit)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSearchLength bounds what a brewer can type into a search box.
+const MaxSearchLength = 100
+
+// fuzzyTermLength is the shortest search word allowed one typo; shorter
+// words would match almost anything.
+const fuzzyTermLength = 4
+
+// FoldSearchText reduces text to the form searches compare: lower case,
+// without accents or other combining marks, so a search typed without them,
+// such as "cafe" for "Café" or "чаинии" for "Чайний", still matches.
+func FoldSearchText(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return norm.NFC.String(b.String())
+}
+
+// SearchKind says what a SearchDocument was made from.
+type SearchKind string
+
+const (
+	BrewSearchKind   SearchKind = "brew"
+	RecordSearchKind SearchKind = "record"
+	NoteSearchKind   SearchKind = "note"
+)
+
+// SearchDocument is the text of a jar's name, a record's ingredients or a
+// note, as the full-text index holds it. RecordID is empty for a jar.
+type SearchDocument struct {
+	Kind      SearchKind
+	ID        string
+	SessionID string
+	BrewID    string
+	RecordID  string
+	Text      string
+	At        time.Time
+}
+
+// SearchHit is a document matching a search. A higher Score is a better
+// match; scores only compare within one search.
+type SearchHit struct {
+	Document SearchDocument
+	Score    float64
+}
+
+func NewBrewSearchDocument(brew *Brew) *SearchDocument {
+	return &SearchDocument{
+		Kind:      BrewSearchKind,
+		ID:        brew.ID,
+		SessionID: brew.SessionID,
+		BrewID:    brew.ID,
+		Text:      brew.Name,
+		At:        brew.CreatedAt,
+	}
+}
+
+// NewRecordSearchDocument files the record under its jar's owner, the only
+// session that may read it; NewNoteSearchDocument does the same for notes.
+func NewRecordSearchDocument(brew *Brew, record *BrewRecord) *SearchDocument {
+	return &SearchDocument{
+		Kind:      RecordSearchKind,
+		ID:        record.ID,
+		SessionID: brew.SessionID,
+		BrewID:    record.BrewID,
+		RecordID:  record.ID,
+		Text:      record.Recipe.SearchText(),
+		At:        record.CreatedAt,
+	}
+}
+
+func NewNoteSearchDocument(brew *Brew, record *BrewRecord, note *RecordNote) *SearchDocument {
+	return &SearchDocument{
+		Kind:      NoteSearchKind,
+		ID:        note.ID,
+		SessionID: brew.SessionID,
+		BrewID:    record.BrewID,
+		RecordID:  record.ID,
+		Text:      note.Text,
+		At:        note.CreatedAt,
+	}
+}
+
+// SearchText lists what went into the jar, e.g. "hibiscus, ginger, green
+// tea, cane sugar", so that a batch can be found by any ingredient.
+func (r Recipe) SearchText() string {
+	var parts []string
+	for _, extra := range r.Extras {
+		parts = append(parts, extra.Name)
+	}
+	if r.TeaType != "" && r.TeaType != OtherTea {
+		parts = append(parts, string(r.TeaType)+" tea")
+	}
+	if r.SugarType == Honey {
+		parts = append(parts, string(r.SugarType))
+	} else if r.SugarType != "" && r.SugarType != OtherSugar {
+		parts = append(parts, string(r.SugarType)+" sugar")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SearchTerms splits a search into folded words.
+func SearchTerms(search string) []string {
+	return strings.FieldsFunc(FoldSearchText(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// MatchesSearch reports whether every word of search occurs in text, either
+// as a substring or, for words of fuzzyTermLength or more, with one typo
+// against a word of text or its beginning. An empty search matches all.
+func MatchesSearch(text string, search string) bool {
+	folded := FoldSearchText(text)
+	words := SearchTerms(text)
+	for _, term := range SearchTerms(search) {
+		if !matchesTerm(folded, words, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTerm(folded string, words []string, term string) bool {
+	if strings.Contains(folded, term) {
+		return true
+	}
+	runes := []rune(term)
+	if len(runes) < fuzzyTermLength {
+		return false
+	}
+	for _, word := range words {
+		wordRunes := []rune(word)
+		if editDistance(runes, wordRunes) <= 1 {
+			return true
+		}
+		// The brewer may still be typing: compare with the word's start.
+		for _, length := range []int{len(runes) - 1, len(runes), len(runes) + 1} {
+			if length < len(wordRunes) && editDistance(runes, wordRunes[:length]) <= 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// editDistance is the Damerau-Levenshtein distance (optimal string
+// alignment): insertions, deletions, substitutions and swaps of neighbours
+// all count as one edit.
+func editDistance(a []rune, b []rune) int {
+	previous2 := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(b)]
+}
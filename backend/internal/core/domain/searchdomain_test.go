@@ -0,0 +1,62 @@
+package domain
+
+import "testing"
+
+func TestMatchesSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		search string
+		want   bool
+	}{
+		{name: "empty search", text: "Скубі", search: "  ", want: true},
+		{name: "any case", text: "Чайний гриб", search: "ЧАЙНИЙ", want: true},
+		{name: "without diacritics", text: "Чайний гриб", search: "чаинии", want: true},
+		{name: "without diacritics in Latin", text: "Café crème", search: "cafe creme", want: true},
+		{name: "combining marks in text", text: "Чайний гриб", search: "чайний", want: true},
+		{name: "substring", text: "Kombucha", search: "bucha", want: true},
+		{name: "one typo", text: "Kombucha", search: "kombuca", want: true},
+		{name: "swapped letters", text: "Kombucha", search: "kobmucha", want: true},
+		{name: "typo while typing", text: "Kombucha", search: "kpmbu", want: true},
+		{name: "two typos", text: "Kombucha", search: "kpmbuxa", want: false},
+		{name: "short words must match exactly", text: "Green tea", search: "tex", want: false},
+		{name: "every word must match", text: "Зелений чай", search: "чай зелений", want: true},
+		{name: "one word missing", text: "Зелений чай", search: "чорний чай", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesSearch(tt.text, tt.search); got != tt.want {
+				t.Fatalf("MatchesSearch(%q, %q) = %v, want %v", tt.text, tt.search, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecipe_SearchText(t *testing.T) {
+	tests := []struct {
+		name   string
+		recipe Recipe
+		want   string
+	}{
+		{name: "nothing named", recipe: Recipe{Water: Quantity{Amount: 3, Unit: Liters}}, want: ""},
+		{
+			name: "extras then tea and sugar",
+			recipe: Recipe{
+				TeaType:   GreenTea,
+				SugarType: CaneSugar,
+				Extras:    []Ingredient{{Name: "каркаде"}, {Name: "ginger"}},
+			},
+			want: "каркаде, ginger, green tea, cane sugar",
+		},
+		{name: "honey is not a sugar", recipe: Recipe{TeaType: OtherTea, SugarType: Honey}, want: "honey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.recipe.SearchText(); got != tt.want {
+				t.Fatalf("SearchText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
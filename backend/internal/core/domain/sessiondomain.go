@@ -1,9 +1,15 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
+// MaxSessionIDLength leaves room for a UUID or any other random ID a client
+// generates for itself.
+const MaxSessionIDLength = 64
+
 type Session struct {
 	ID           string
 	CreatedAt    time.Time
@@ -11,6 +17,9 @@ type Session struct {
 	ExpiresAt    *time.Time
 	IsActive     bool
 	ShareTokens  []ShareToken
+	// Version is bumped by every successful repository Update. Touching
+	// LastAccessed is bookkeeping and leaves it alone.
+	Version int64
 }
 
 type ShareToken struct {
@@ -27,3 +36,78 @@ const (
 	ReadOnlyScope  ShareScope = "read-only"
 	ReadWriteScope ShareScope = "read-write"
 )
+
+// IsExpired reports whether the session ran past its ExpiresAt or has not
+// been used for longer than ttl.
+func (s *Session) IsExpired(now time.Time, ttl time.Duration) bool {
+	if s.ExpiresAt != nil && !now.Before(*s.ExpiresAt) {
+		return true
+	}
+	return now.Sub(s.LastAccessed) > ttl
+}
+
+// ValidateSessionID checks the ID a client chose for a new session. The ID
+// is the session's only credential and travels in headers and URL paths,
+// so only ASCII letters, digits, '-' and '_' are allowed.
+func ValidateSessionID(id string) error {
+	switch {
+	case id == "":
+		return InvalidField("id", "is required")
+	case len(id) > MaxSessionIDLength:
+		return InvalidField("id", fmt.Sprintf("must be at most %d characters", MaxSessionIDLength))
+	case strings.IndexFunc(id, func(r rune) bool { return !isSessionIDRune(r) }) >= 0:
+		return InvalidField("id", "must contain only letters, digits, '-' and '_'")
+	}
+	return nil
+}
+
+func isSessionIDRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_'
+}
+
+// Deactivate stops the session and every share token it issued.
+func (s *Session) Deactivate() {
+	s.IsActive = false
+	for i := range s.ShareTokens {
+		s.ShareTokens[i].IsActive = false
+	}
+}
+
+// Purge deactivates the session and drops its share tokens. The session
+// stays behind as a tombstone: its jars remain unreachable and its ID can't
+// be claimed again by a new session.
+func (s *Session) Purge() {
+	s.IsActive = false
+	s.ShareTokens = nil
+}
+
+func (s ShareScope) IsKnown() bool {
+	return s == ReadOnlyScope || s == ReadWriteScope
+}
+
+// IsUsable reports whether the token still grants access at the given time.
+func (t ShareToken) IsUsable(now time.Time) bool {
+	return t.IsActive && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// FindShareToken returns the session's token with the given value, or nil.
+func (s *Session) FindShareToken(token string) *ShareToken {
+	for i := range s.ShareTokens {
+		if s.ShareTokens[i].Token == token {
+			return &s.ShareTokens[i]
+		}
+	}
+	return nil
+}
+
+// ShareGrant is the access a visitor holding a share token has to the jars
+// of the session that issued it.
+type ShareGrant struct {
+	SessionID string
+	Token     string
+	Scope     ShareScope
+}
+
+func (g ShareGrant) CanWrite() bool {
+	return g.Scope == ReadWriteScope
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxSyncOperations bounds one sync batch; clients with more queued edits
+// send several batches.
+const MaxSyncOperations = 100
+
+// SyncReferencePrefix lets an operation point at an entity created earlier
+// by another operation, e.g. BrewID "op:<uuid>" for a jar created offline
+// before the server assigned its ID.
+const SyncReferencePrefix = "op:"
+
+type SyncOperationType string
+
+const (
+	SyncCreateBrew            SyncOperationType = "brew.create"
+	SyncRenameBrew            SyncOperationType = "brew.rename"
+	SyncAddRecord             SyncOperationType = "record.add"
+	SyncAppendNote            SyncOperationType = "note.append"
+	SyncAddTimelineEvent      SyncOperationType = "timeline.add"
+	SyncCompleteTimelineEvent SyncOperationType = "timeline.complete"
+	SyncAddEvaluation         SyncOperationType = "evaluation.add"
+)
+
+func (t SyncOperationType) IsKnown() bool {
+	switch t {
+	case SyncCreateBrew, SyncRenameBrew, SyncAddRecord, SyncAppendNote, SyncAddTimelineEvent, SyncCompleteTimelineEvent, SyncAddEvaluation:
+		return true
+	default:
+		return false
+	}
+}
+
+// SyncOperation is one edit a client made offline, identified by a UUID the
+// client chose so that sending it again never applies it twice. Only the
+// fields its Type needs are read.
+type SyncOperation struct {
+	ID   string
+	Type SyncOperationType
+
+	BrewID   string
+	RecordID string
+	EventID  string
+
+	Name        string
+	Recipe      Recipe
+	Text        string
+	EventType   TimelineEventType
+	Title       string
+	At          time.Time
+	Score       QualityScore
+	Notes       string
+	Suggestions string
+	// Version is the jar version the client edited offline; the edit is
+	// rejected with a conflict if the jar changed on the server meanwhile.
+	Version *int64
+}
+
+// SyncReference returns the operation ID an "op:" reference points at.
+func SyncReference(value string) (string, bool) {
+	return strings.CutPrefix(value, SyncReferencePrefix)
+}
+
+type SyncStatus string
+
+const (
+	// SyncPending marks an operation that is being applied right now.
+	SyncPending SyncStatus = "pending"
+	SyncApplied SyncStatus = "applied"
+	// SyncRejected operations failed for good, e.g. on validation; sending
+	// them again returns the same rejection.
+	SyncRejected SyncStatus = "rejected"
+	// SyncFailed operations hit a transient error and may be sent again.
+	SyncFailed SyncStatus = "failed"
+	// SyncConflict operations edited a jar that changed since the client's
+	// Version. They are not remembered either: the client rebases the edit
+	// on the result's Version and sends it again.
+	SyncConflict SyncStatus = "conflict"
+)
+
+// SyncResult is what the server remembers about an operation: its outcome
+// and the ID of the entity it created or touched.
+type SyncResult struct {
+	OperationID string
+	SessionID   string
+	Type        SyncOperationType
+	Status      SyncStatus
+	EntityID    string
+	Error       string
+	ReceivedAt  time.Time
+	// Version is the jar's current version on a SyncConflict result.
+	Version *int64
+}
+
+// SyncBatch answers one sync call: the outcome of every operation sent and
+// the changes made in the session since the client's cursor.
+type SyncBatch struct {
+	Results []*SyncResult
+	Changes []*AuditEntry
+	Cursor  int64
+	HasMore bool
+}
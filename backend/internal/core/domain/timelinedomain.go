@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultFermentationPeriod is used to estimate the harvest of a batch when
+// the brewer did not schedule one explicitly.
+const DefaultFermentationPeriod = 10 * 24 * time.Hour
+
+type TimelineEventType string
+
+const (
+	StartEvent            TimelineEventType = "start"
+	EstimatedHarvestEvent TimelineEventType = "estimated_harvest"
+	RefillEvent           TimelineEventType = "refill"
+	BottlingEvent         TimelineEventType = "f2_bottling"
+	CustomEvent           TimelineEventType = "custom"
+)
+
+func (t TimelineEventType) IsKnown() bool {
+	switch t {
+	case StartEvent, EstimatedHarvestEvent, RefillEvent, BottlingEvent, CustomEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+type TimelineEvent struct {
+	ID          string
+	BrewID      string
+	SessionID   string
+	Type        TimelineEventType
+	Title       string
+	At          time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+}
+
+func NewTimelineEvent(
+	id string,
+	brewID string,
+	sessionID string,
+	eventType TimelineEventType,
+	title string,
+	at time.Time,
+	now time.Time,
+) *TimelineEvent {
+	event := &TimelineEvent{
+		ID:        id,
+		BrewID:    brewID,
+		SessionID: sessionID,
+		Type:      eventType,
+		Title:     title,
+		At:        at,
+		CreatedAt: now,
+	}
+	// Starting a batch is something that happened, not something to do.
+	if eventType == StartEvent {
+		event.CompletedAt = &at
+	}
+	return event
+}
+
+func (e *TimelineEvent) Complete(at time.Time) {
+	if e.CompletedAt != nil {
+		return
+	}
+	e.CompletedAt = &at
+}
+
+func (e *TimelineEvent) IsPending() bool {
+	return e.CompletedAt == nil
+}
+
+type NextAction struct {
+	Type TimelineEventType
+	// Event is nil when the action was derived rather than scheduled.
+	Event   *TimelineEvent
+	DueAt   time.Time
+	Overdue bool
+}
+
+// ComputeNextAction picks what the brewer should do next with a jar: the
+// earliest pending scheduled event, or, when nothing is scheduled, the
+// harvest of the running batch estimated from DefaultFermentationPeriod.
+// It returns nil when there is nothing to do.
+func ComputeNextAction(events []*TimelineEvent, now time.Time) *NextAction {
+	sorted := append([]*TimelineEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].At.Before(sorted[j].At)
+	})
+
+	for _, event := range sorted {
+		if event.IsPending() {
+			return &NextAction{
+				Type:    event.Type,
+				Event:   event,
+				DueAt:   event.At,
+				Overdue: event.At.Before(now),
+			}
+		}
+	}
+
+	var batchStart *time.Time
+	for _, event := range sorted {
+		switch event.Type {
+		case StartEvent, RefillEvent:
+			at := event.At
+			batchStart = &at
+		case EstimatedHarvestEvent:
+			batchStart = nil
+		}
+	}
+	if batchStart == nil {
+		return nil
+	}
+
+	dueAt := batchStart.Add(DefaultFermentationPeriod)
+	return &NextAction{
+		Type:    EstimatedHarvestEvent,
+		DueAt:   dueAt,
+		Overdue: dueAt.Before(now),
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeNextAction(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	event := func(eventType TimelineEventType, at time.Time, completed bool) *TimelineEvent {
+		e := NewTimelineEvent("id", "brew", "session", eventType, "", at, at)
+		if completed {
+			e.Complete(at)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name        string
+		events      []*TimelineEvent
+		wantNil     bool
+		wantType    TimelineEventType
+		wantDueAt   time.Time
+		wantOverdue bool
+		wantDerived bool
+	}{
+		{
+			name:    "empty timeline",
+			wantNil: true,
+		},
+		{
+			name: "earliest pending event wins",
+			events: []*TimelineEvent{
+				event(BottlingEvent, now.Add(5*day), false),
+				event(StartEvent, now.Add(-3*day), true),
+				event(CustomEvent, now.Add(2*day), false),
+			},
+			wantType:  CustomEvent,
+			wantDueAt: now.Add(2 * day),
+		},
+		{
+			name: "pending event in the past is overdue",
+			events: []*TimelineEvent{
+				event(EstimatedHarvestEvent, now.Add(-day), false),
+			},
+			wantType:    EstimatedHarvestEvent,
+			wantDueAt:   now.Add(-day),
+			wantOverdue: true,
+		},
+		{
+			name: "harvest derived from latest refill",
+			events: []*TimelineEvent{
+				event(StartEvent, now.Add(-20*day), true),
+				event(RefillEvent, now.Add(-2*day), true),
+			},
+			wantType:    EstimatedHarvestEvent,
+			wantDueAt:   now.Add(-2*day + DefaultFermentationPeriod),
+			wantDerived: true,
+		},
+		{
+			name: "nothing to do after harvest",
+			events: []*TimelineEvent{
+				event(StartEvent, now.Add(-12*day), true),
+				event(EstimatedHarvestEvent, now.Add(-day), true),
+			},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeNextAction(tt.events, now)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("ComputeNextAction() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("ComputeNextAction() = nil")
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", got.Type, tt.wantType)
+			}
+			if !got.DueAt.Equal(tt.wantDueAt) {
+				t.Errorf("DueAt = %v, want %v", got.DueAt, tt.wantDueAt)
+			}
+			if got.Overdue != tt.wantOverdue {
+				t.Errorf("Overdue = %v, want %v", got.Overdue, tt.wantOverdue)
+			}
+			if (got.Event == nil) != tt.wantDerived {
+				t.Errorf("Event = %v, want derived = %v", got.Event, tt.wantDerived)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TransferToken hands one jar over to whichever session redeems it first.
+// The jar's records always go with it: records are only ever reached
+// through their jar, so they cannot stay behind with the old session.
+type TransferToken struct {
+	Token         string
+	BrewID        string
+	FromSessionID string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	ConsumedAt    *time.Time
+	ConsumedBy    string
+}
+
+// IsUsable reports whether the token can still be redeemed at now.
+func (t *TransferToken) IsUsable(now time.Time) bool {
+	return t.ConsumedAt == nil && now.Before(t.ExpiresAt)
+}
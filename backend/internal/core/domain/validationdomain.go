@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Limits are counted in characters (runes after NFC normalization), not
+// bytes, so a Cyrillic name gets as much room as a Latin one.
+const (
+	MaxBrewNameLength       = 80
+	MaxIngredientNameLength = 60
+	MaxBrewDetailLength     = 120
+	MaxTextLength           = 4000
+	MaxExtras               = 20
+)
+
+// maxBaseAmount caps quantities per dimension in base units: 100 l of
+// liquid, 10 kg of solids or 1000 pieces is far beyond any home batch, so
+// anything larger is a typo such as grams entered as milligrams.
+var maxBaseAmount = map[dimension]float64{
+	volume: 100_000,
+	mass:   10_000,
+	count:  1_000,
+}
+
+// NormalizeName puts a single-line name typed on any device into one
+// canonical form: NFC, trimmed, with every run of whitespace turned into a
+// single space. "Скубі" typed with a combining breve is then stored the same
+// as with the precomposed letter.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// NormalizeText is NormalizeName for free text: line breaks are kept and
+// only the ends are trimmed.
+func NormalizeText(text string) string {
+	return strings.TrimSpace(norm.NFC.String(text))
+}
+
+// ValidateName normalizes a required name and reports it under field when
+// it is empty, too long or contains control characters.
+func ValidateName(invalid *ValidationError, field string, name string, maxLength int) string {
+	name = NormalizeName(name)
+	switch {
+	case name == "":
+		invalid.Add(field, "is required")
+	case utf8.RuneCountInString(name) > maxLength:
+		invalid.Add(field, fmt.Sprintf("must be at most %d characters", maxLength))
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		invalid.Add(field, "must not contain control characters")
+	}
+	return name
+}
+
+// ValidateText normalizes free text and reports it under field when it is
+// longer than MaxTextLength, or empty while required.
+func ValidateText(invalid *ValidationError, field string, text string, required bool) string {
+	text = NormalizeText(text)
+	switch {
+	case required && text == "":
+		invalid.Add(field, "is required")
+	case utf8.RuneCountInString(text) > MaxTextLength:
+		invalid.Add(field, fmt.Sprintf("must be at most %d characters", MaxTextLength))
+	}
+	return text
+}
+
+// NormalizeBrewName returns the name a jar is stored under, or a
+// ValidationError for field "name".
+func NormalizeBrewName(name string) (string, error) {
+	invalid := &ValidationError{}
+	name = ValidateName(invalid, "name", name, MaxBrewNameLength)
+	return name, invalid.Err()
+}
+
+// Normalize returns the changes with every text field normalized; Validate
+// expects normalized changes.
+func (c BrewChanges) Normalize() BrewChanges {
+	normalized := c
+	normalized.Name = normalizeOptionalName(c.Name)
+	normalized.Location = normalizeOptionalName(c.Location)
+	normalized.ScobyOrigin = normalizeOptionalName(c.ScobyOrigin)
+	return normalized
+}
+
+func normalizeOptionalName(name *string) *string {
+	if name == nil {
+		return nil
+	}
+	normalized := NormalizeName(*name)
+	return &normalized
+}
+
+// Validate reports every problem with the changes at once. The name may be
+// changed but not cleared; the optional details may be cleared.
+func (c BrewChanges) Validate() error {
+	invalid := &ValidationError{}
+
+	if c.Name != nil {
+		ValidateName(invalid, "name", *c.Name, MaxBrewNameLength)
+	}
+	if c.VesselVolume != nil {
+		validateQuantity(invalid, "vessel_volume", *c.VesselVolume, volume)
+	}
+	if c.Location != nil && *c.Location != "" {
+		ValidateName(invalid, "location", *c.Location, MaxBrewDetailLength)
+	}
+	if c.ColorTag != nil && *c.ColorTag != "" && !c.ColorTag.IsKnown() {
+		invalid.Add("color_tag", fmt.Sprintf("%q is not a colour tag", *c.ColorTag))
+	}
+	if c.ScobyOrigin != nil && *c.ScobyOrigin != "" {
+		ValidateName(invalid, "scoby_origin", *c.ScobyOrigin, MaxBrewDetailLength)
+	}
+
+	return invalid.Err()
+}
+
+// Normalize returns the query with the search normalized and the default
+// sort, oldest first, filled in.
+func (q BrewQuery) Normalize() BrewQuery {
+	normalized := q
+	normalized.Search = NormalizeName(q.Search)
+	if normalized.Sort == "" {
+		normalized.Sort = SortByCreated
+	}
+	return normalized
+}
+
+func (q BrewQuery) Validate() error {
+	invalid := &ValidationError{}
+
+	if utf8.RuneCountInString(q.Search) > MaxSearchLength {
+		invalid.Add("search", fmt.Sprintf("must be at most %d characters", MaxSearchLength))
+	}
+	if !q.Status.IsKnown() {
+		invalid.Add("status", fmt.Sprintf("must be %s or %s", ActiveBrewStatus, ArchivedBrewStatus))
+	}
+	if !q.Sort.IsKnown() {
+		invalid.Add("sort", fmt.Sprintf("must be %s, %s or %s", SortByCreated, SortByUpdated, SortByNextAction))
+	}
+
+	return invalid.Err()
+}
+
+// NormalizeSearch returns the normalized full-text search, or a
+// ValidationError for field "search" when it has nothing to look for.
+func NormalizeSearch(search string) (string, error) {
+	invalid := &ValidationError{}
+	search = NormalizeName(search)
+	switch {
+	case len(SearchTerms(search)) == 0:
+		invalid.Add("search", "must contain a letter or digit")
+	case utf8.RuneCountInString(search) > MaxSearchLength:
+		invalid.Add("search", fmt.Sprintf("must be at most %d characters", MaxSearchLength))
+	}
+	return search, invalid.Err()
+}
+
+// Normalize returns the recipe with ingredient names normalized; Validate
+// expects a normalized recipe.
+func (r Recipe) Normalize() Recipe {
+	normalized := r
+	normalized.Extras = make([]Ingredient, 0, len(r.Extras))
+	for _, extra := range r.Extras {
+		extra.Name = NormalizeName(extra.Name)
+		normalized.Extras = append(normalized.Extras, extra)
+	}
+	return normalized
+}
+
+// Validate reports every problem with the recipe at once, with field names
+// matching the recipe's JSON shape, e.g. "recipe.extras[1].name".
+func (r Recipe) Validate() error {
+	invalid := &ValidationError{}
+
+	// A negative or otherwise unusable amount is reported by validateQuantity.
+	if r.Water.IsZero() {
+		invalid.Add("recipe.water.amount", "is required")
+	}
+	validateQuantity(invalid, "recipe.water", r.Water, volume)
+
+	if r.SugarType != "" && !r.SugarType.IsKnown() {
+		invalid.Add("recipe.sugar_type", fmt.Sprintf("%q is not a sugar type", r.SugarType))
+	}
+	validateQuantity(invalid, "recipe.sugar", r.Sugar, mass, volume)
+
+	if r.TeaType != "" && !r.TeaType.IsKnown() {
+		invalid.Add("recipe.tea_type", fmt.Sprintf("%q is not a tea type", r.TeaType))
+	}
+	// Tea is weighed, spooned or counted in bags.
+	validateQuantity(invalid, "recipe.tea", r.Tea, mass, volume, count)
+
+	if len(r.Extras) > MaxExtras {
+		invalid.Add("recipe.extras", fmt.Sprintf("must not have more than %d ingredients", MaxExtras))
+	}
+	for i, extra := range r.Extras {
+		field := fmt.Sprintf("recipe.extras[%d]", i)
+		ValidateName(invalid, field+".name", extra.Name, MaxIngredientNameLength)
+		validateQuantity(invalid, field+".quantity", extra.Quantity, volume, mass, count)
+	}
+
+	return invalid.Err()
+}
+
+// validateQuantity accepts a zero amount as "not measured"; anything else
+// needs a unit of one of the allowed dimensions and a plausible amount.
+func validateQuantity(invalid *ValidationError, field string, q Quantity, allowed ...dimension) {
+	if math.IsNaN(q.Amount) || math.IsInf(q.Amount, 0) || q.Amount < 0 {
+		invalid.Add(field+".amount", "must be a positive number")
+		return
+	}
+	if q.IsZero() {
+		return
+	}
+
+	info, ok := units[q.Unit]
+	if !ok {
+		invalid.Add(field+".unit", fmt.Sprintf("%q is not a unit", q.Unit))
+		return
+	}
+	fits := false
+	for _, dimension := range allowed {
+		fits = fits || info.dimension == dimension
+	}
+	if !fits {
+		invalid.Add(field+".unit", fmt.Sprintf("%s cannot measure this ingredient", q.Unit))
+		return
+	}
+	if q.Amount*info.base > maxBaseAmount[info.dimension] {
+		invalid.Add(field+".amount", "is implausibly large")
+	}
+}
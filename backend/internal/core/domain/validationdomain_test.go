@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBrewName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "trims and collapses spaces", input: "  Чайний \t гриб  ", want: "Чайний гриб"},
+		{name: "composes combining marks", input: "Чайнии\u0306 гриб", want: "Чайний гриб"},
+		{name: "counts characters not bytes", input: strings.Repeat("ї", MaxBrewNameLength), want: strings.Repeat("ї", MaxBrewNameLength)},
+		{name: "empty", input: " \n ", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", MaxBrewNameLength+1), wantErr: true},
+		{name: "control character", input: "jar\x00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBrewName(tt.input)
+			if tt.wantErr {
+				var invalid *ValidationError
+				if !errors.As(err, &invalid) || invalid.Fields[0].Field != "name" {
+					t.Fatalf("NormalizeBrewName(%q) error = %v, want a name field error", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeBrewName(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeBrewName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecipe_ValidateAcceptsMinimalRecipe(t *testing.T) {
+	recipe := Recipe{
+		Water: Quantity{Amount: 3, Unit: Liters},
+		Tea:   Quantity{Amount: 6, Unit: Pieces},
+		Extras: []Ingredient{
+			{Name: "  імбир ", Quantity: Quantity{Amount: 20, Unit: Grams}},
+		},
+	}.Normalize()
+
+	if err := recipe.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if recipe.Extras[0].Name != "імбир" {
+		t.Fatalf("Normalize() extra name = %q, want %q", recipe.Extras[0].Name, "імбир")
+	}
+}
+
+func TestRecipe_ValidateReportsEveryField(t *testing.T) {
+	recipe := Recipe{
+		Water:     Quantity{Amount: 3, Unit: Grams},
+		SugarType: "maple",
+		Sugar:     Quantity{Amount: 200, Unit: Pieces},
+		TeaType:   GreenTea,
+		Tea:       Quantity{Amount: math.NaN(), Unit: Grams},
+		Extras: []Ingredient{
+			{Name: "", Quantity: Quantity{Amount: 1, Unit: "cup"}},
+			{Name: "mint", Quantity: Quantity{Amount: 50, Unit: Kilograms}},
+		},
+	}
+
+	err := recipe.Validate()
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Validate() error = %v, want ValidationError", err)
+	}
+	got := make(map[string]bool)
+	for _, field := range invalid.Fields {
+		got[field.Field] = true
+	}
+	for _, want := range []string{
+		"recipe.water.unit",
+		"recipe.sugar_type",
+		"recipe.sugar.unit",
+		"recipe.tea.amount",
+		"recipe.extras[0].name",
+		"recipe.extras[0].quantity.unit",
+		"recipe.extras[1].quantity.amount",
+	} {
+		if !got[want] {
+			t.Errorf("Validate() fields = %+v, missing %s", invalid.Fields, want)
+		}
+	}
+	if len(invalid.Fields) != 7 {
+		t.Errorf("Validate() reported %d fields, want 7: %+v", len(invalid.Fields), invalid.Fields)
+	}
+}
+
+func TestRecipe_ValidateRequiresWater(t *testing.T) {
+	for _, water := range []Quantity{{}, {Amount: -2, Unit: Liters}, {Amount: math.NaN(), Unit: Liters}} {
+		err := Recipe{Water: water}.Validate()
+
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) || len(invalid.Fields) != 1 || invalid.Fields[0].Field != "recipe.water.amount" {
+			t.Errorf("Validate(water %v) error = %v, want a single recipe.water.amount", water.Amount, err)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// Clock is where services read the current time, so that tests can pin it
+// and timestamps stay ordered the way the test expects.
+type Clock interface {
+	Now() time.Time
+}
@@ -5,4 +5,7 @@ import "context"
 type IdentifierGenerator interface {
 	Generate(ctx context.Context, name string) (string, error)
 	Validate(ctx context.Context, identifier string) error
+	// Normalize validates a scanned or hand-typed identifier and returns
+	// it in the canonical form brews are stored under.
+	Normalize(ctx context.Context, identifier string) (string, error)
 }
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+)
+
+type LabelSheetRenderer interface {
+	// RenderLabelSheet returns one printable document per page of the sheet.
+	RenderLabelSheet(
+		ctx context.Context,
+		sheet domain.LabelSheet,
+	) ([][]byte, error)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.AuditRepository = (*AuditRepository)(nil)
+
+type AuditRepository struct {
+	AppendFunc         func(ctx context.Context, entry *domain.AuditEntry) error
+	GetByBrewIDFunc    func(ctx context.Context, brewID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.AuditEntry], error)
+	GetBySessionIDFunc func(ctx context.Context, sessionID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.AuditEntry], error)
+	GetSinceFunc       func(ctx context.Context, sessionID string, afterSeq int64, limit int) ([]*domain.AuditEntry, error)
+}
+
+func (m *AuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	if m.AppendFunc != nil {
+		return m.AppendFunc(ctx, entry)
+	}
+	return nil
+}
+
+func (m *AuditRepository) GetByBrewID(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	if m.GetByBrewIDFunc != nil {
+		return m.GetByBrewIDFunc(ctx, brewID, pointer, limit)
+	}
+	return nil, nil
+}
+
+func (m *AuditRepository) GetBySessionID(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	if m.GetBySessionIDFunc != nil {
+		return m.GetBySessionIDFunc(ctx, sessionID, pointer, limit)
+	}
+	return nil, nil
+}
+
+func (m *AuditRepository) GetSince(
+	ctx context.Context,
+	sessionID string,
+	afterSeq int64,
+	limit int,
+) ([]*domain.AuditEntry, error) {
+	if m.GetSinceFunc != nil {
+		return m.GetSinceFunc(ctx, sessionID, afterSeq, limit)
+	}
+	return nil, nil
+}
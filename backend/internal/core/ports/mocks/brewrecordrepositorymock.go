@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.BrewRecordRepository = (*BrewRecordRepository)(nil)
+
+type BrewRecordRepository struct {
+	SaveFunc        func(ctx context.Context, record *domain.BrewRecord) error
+	GetByIDFunc     func(ctx context.Context, id string) (*domain.BrewRecord, error)
+	GetByBrewIDFunc func(ctx context.Context, brewID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.BrewRecord], error)
+	UpdateFunc      func(ctx context.Context, record *domain.BrewRecord) error
+	DeleteFunc      func(ctx context.Context, id string) error
+	AppendNoteFunc  func(ctx context.Context, note *domain.RecordNote) error
+	GetNotesFunc    func(ctx context.Context, recordID string) ([]*domain.RecordNote, error)
+}
+
+func (m *BrewRecordRepository) Save(ctx context.Context, record *domain.BrewRecord) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, record)
+	}
+	return nil
+}
+
+func (m *BrewRecordRepository) GetByID(ctx context.Context, id string) (*domain.BrewRecord, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *BrewRecordRepository) GetByBrewID(ctx context.Context, brewID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.BrewRecord], error) {
+	if m.GetByBrewIDFunc != nil {
+		return m.GetByBrewIDFunc(ctx, brewID, pointer, limit)
+	}
+	return &ports.PaginatedResult[*domain.BrewRecord]{}, nil
+}
+
+func (m *BrewRecordRepository) Update(ctx context.Context, record *domain.BrewRecord) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, record)
+	}
+	return nil
+}
+
+func (m *BrewRecordRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *BrewRecordRepository) AppendNote(ctx context.Context, note *domain.RecordNote) error {
+	if m.AppendNoteFunc != nil {
+		return m.AppendNoteFunc(ctx, note)
+	}
+	return nil
+}
+
+func (m *BrewRecordRepository) GetNotes(ctx context.Context, recordID string) ([]*domain.RecordNote, error) {
+	if m.GetNotesFunc != nil {
+		return m.GetNotesFunc(ctx, recordID)
+	}
+	return nil, nil
+}
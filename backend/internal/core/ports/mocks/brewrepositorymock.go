@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"brew/internal/core/domain"
 	"brew/internal/core/ports"
@@ -13,7 +14,9 @@ type BrewRepository struct {
 	SaveFunc           func(ctx context.Context, brew *domain.Brew) error
 	GetByIDFunc        func(ctx context.Context, id string) (*domain.Brew, error)
 	GetBySessionIDFunc func(ctx context.Context, sessionID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.Brew], error)
+	QueryFunc          func(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error)
 	UpdateFunc         func(ctx context.Context, brew *domain.Brew) error
+	SetNextActionFunc  func(ctx context.Context, id string, at *time.Time) error
 	ExistsFunc         func(ctx context.Context, id string) (bool, error)
 }
 
@@ -38,6 +41,13 @@ func (m *BrewRepository) GetBySessionID(ctx context.Context, sessionID string, p
 	return &ports.PaginatedResult[*domain.Brew]{}, nil
 }
 
+func (m *BrewRepository) Query(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error) {
+	if m.QueryFunc != nil {
+		return m.QueryFunc(ctx, query)
+	}
+	return &ports.PaginatedResult[*domain.Brew]{}, nil
+}
+
 func (m *BrewRepository) Update(ctx context.Context, brew *domain.Brew) error {
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, brew)
@@ -45,6 +55,13 @@ func (m *BrewRepository) Update(ctx context.Context, brew *domain.Brew) error {
 	return nil
 }
 
+func (m *BrewRepository) SetNextAction(ctx context.Context, id string, at *time.Time) error {
+	if m.SetNextActionFunc != nil {
+		return m.SetNextActionFunc(ctx, id, at)
+	}
+	return nil
+}
+
 func (m *BrewRepository) Exists(ctx context.Context, id string) (bool, error) {
 	if m.ExistsFunc != nil {
 		return m.ExistsFunc(ctx, id)
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"time"
+
+	"brew/internal/core/ports"
+)
+
+var _ ports.Clock = (*Clock)(nil)
+
+type Clock struct {
+	NowFunc func() time.Time
+}
+
+func (m *Clock) Now() time.Time {
+	if m.NowFunc != nil {
+		return m.NowFunc()
+	}
+	return time.Time{}
+}
@@ -9,8 +9,9 @@ import (
 var _ ports.IdentifierGenerator = (*IdentifierGenerator)(nil)
 
 type IdentifierGenerator struct {
-	GenerateFunc func(ctx context.Context, name string) (string, error)
-	ValidateFunc func(ctx context.Context, identifier string) error
+	GenerateFunc  func(ctx context.Context, name string) (string, error)
+	ValidateFunc  func(ctx context.Context, identifier string) error
+	NormalizeFunc func(ctx context.Context, identifier string) (string, error)
 }
 
 func (m *IdentifierGenerator) Generate(ctx context.Context, name string) (string, error) {
@@ -26,3 +27,10 @@ func (m *IdentifierGenerator) Validate(ctx context.Context, identifier string) e
 	}
 	return nil
 }
+
+func (m *IdentifierGenerator) Normalize(ctx context.Context, identifier string) (string, error) {
+	if m.NormalizeFunc != nil {
+		return m.NormalizeFunc(ctx, identifier)
+	}
+	return "", nil
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.LabelSheetRenderer = (*LabelSheetRenderer)(nil)
+
+type LabelSheetRenderer struct {
+	RenderLabelSheetFunc func(ctx context.Context, sheet domain.LabelSheet) ([][]byte, error)
+}
+
+func (m *LabelSheetRenderer) RenderLabelSheet(ctx context.Context, sheet domain.LabelSheet) ([][]byte, error) {
+	if m.RenderLabelSheetFunc != nil {
+		return m.RenderLabelSheetFunc(ctx, sheet)
+	}
+	return nil, nil
+}
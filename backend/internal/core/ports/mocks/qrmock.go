@@ -9,13 +9,13 @@ import (
 var _ ports.QRCodeGenerator = (*QRCodeGenerator)(nil)
 
 type QRCodeGenerator struct {
-	GenerateQRCodeFunc func(ctx context.Context, brewID string) ([]byte, error)
+	GenerateQRCodeFunc func(ctx context.Context, brewID string, format ports.QRFormat) ([]byte, error)
 	ParseQRCodeFunc    func(ctx context.Context, qrData []byte) (string, error)
 }
 
-func (m *QRCodeGenerator) GenerateQRCode(ctx context.Context, brewID string) ([]byte, error) {
+func (m *QRCodeGenerator) GenerateQRCode(ctx context.Context, brewID string, format ports.QRFormat) ([]byte, error) {
 	if m.GenerateQRCodeFunc != nil {
-		return m.GenerateQRCodeFunc(ctx, brewID)
+		return m.GenerateQRCodeFunc(ctx, brewID, format)
 	}
 	return nil, nil
 }
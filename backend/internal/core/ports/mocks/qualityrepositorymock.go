@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.QualityRepository = (*QualityRepository)(nil)
+
+type QualityRepository struct {
+	SaveFunc        func(ctx context.Context, evaluation *domain.QualityEvaluation) error
+	GetByIDFunc     func(ctx context.Context, id string) (*domain.QualityEvaluation, error)
+	GetByBrewIDFunc func(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error)
+}
+
+func (m *QualityRepository) Save(ctx context.Context, evaluation *domain.QualityEvaluation) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, evaluation)
+	}
+	return nil
+}
+
+func (m *QualityRepository) GetByID(ctx context.Context, id string) (*domain.QualityEvaluation, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *QualityRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error) {
+	if m.GetByBrewIDFunc != nil {
+		return m.GetByBrewIDFunc(ctx, brewID)
+	}
+	return nil, nil
+}
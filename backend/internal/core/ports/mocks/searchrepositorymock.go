@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SearchRepository = (*SearchRepository)(nil)
+
+type SearchRepository struct {
+	IndexFunc    func(ctx context.Context, document *domain.SearchDocument) error
+	MoveBrewFunc func(ctx context.Context, brewID string, sessionID string) error
+	SearchFunc   func(ctx context.Context, sessionID string, query string, limit int) ([]*domain.SearchHit, error)
+}
+
+func (m *SearchRepository) Index(ctx context.Context, document *domain.SearchDocument) error {
+	if m.IndexFunc != nil {
+		return m.IndexFunc(ctx, document)
+	}
+	return nil
+}
+
+func (m *SearchRepository) MoveBrew(ctx context.Context, brewID string, sessionID string) error {
+	if m.MoveBrewFunc != nil {
+		return m.MoveBrewFunc(ctx, brewID, sessionID)
+	}
+	return nil
+}
+
+func (m *SearchRepository) Search(
+	ctx context.Context,
+	sessionID string,
+	query string,
+	limit int,
+) ([]*domain.SearchHit, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, sessionID, query, limit)
+	}
+	return nil, nil
+}
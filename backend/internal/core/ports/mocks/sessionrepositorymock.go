@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"brew/internal/core/domain"
 	"brew/internal/core/ports"
@@ -10,10 +11,13 @@ import (
 var _ ports.SessionRepository = (*SessionRepository)(nil)
 
 type SessionRepository struct {
-	SaveFunc    func(ctx context.Context, session *domain.Session) error
-	GetByIDFunc func(ctx context.Context, id string) (*domain.Session, error)
-	UpdateFunc  func(ctx context.Context, session *domain.Session) error
-	DeleteFunc  func(ctx context.Context, id string) error
+	SaveFunc            func(ctx context.Context, session *domain.Session) error
+	GetByIDFunc         func(ctx context.Context, id string) (*domain.Session, error)
+	GetByShareTokenFunc func(ctx context.Context, token string) (*domain.Session, error)
+	GetExpiredFunc      func(ctx context.Context, now time.Time, ttl time.Duration) ([]*domain.Session, error)
+	UpdateFunc          func(ctx context.Context, session *domain.Session) error
+	TouchFunc           func(ctx context.Context, id string, at time.Time) error
+	DeleteFunc          func(ctx context.Context, id string) error
 }
 
 func (m *SessionRepository) Save(ctx context.Context, session *domain.Session) error {
@@ -30,6 +34,20 @@ func (m *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Ses
 	return nil, nil
 }
 
+func (m *SessionRepository) GetByShareToken(ctx context.Context, token string) (*domain.Session, error) {
+	if m.GetByShareTokenFunc != nil {
+		return m.GetByShareTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+func (m *SessionRepository) GetExpired(ctx context.Context, now time.Time, ttl time.Duration) ([]*domain.Session, error) {
+	if m.GetExpiredFunc != nil {
+		return m.GetExpiredFunc(ctx, now, ttl)
+	}
+	return nil, nil
+}
+
 func (m *SessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, session)
@@ -37,6 +55,13 @@ func (m *SessionRepository) Update(ctx context.Context, session *domain.Session)
 	return nil
 }
 
+func (m *SessionRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	if m.TouchFunc != nil {
+		return m.TouchFunc(ctx, id, at)
+	}
+	return nil
+}
+
 func (m *SessionRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
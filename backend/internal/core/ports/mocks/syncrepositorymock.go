@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.SyncRepository = (*SyncRepository)(nil)
+
+type SyncRepository struct {
+	ClaimFunc    func(ctx context.Context, result *domain.SyncResult, staleBefore time.Time) (*domain.SyncResult, bool, error)
+	CompleteFunc func(ctx context.Context, result *domain.SyncResult) error
+	ReleaseFunc  func(ctx context.Context, sessionID string, operationID string) error
+	GetFunc      func(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error)
+}
+
+func (m *SyncRepository) Claim(
+	ctx context.Context,
+	result *domain.SyncResult,
+	staleBefore time.Time,
+) (*domain.SyncResult, bool, error) {
+	if m.ClaimFunc != nil {
+		return m.ClaimFunc(ctx, result, staleBefore)
+	}
+	return nil, false, nil
+}
+
+func (m *SyncRepository) Complete(ctx context.Context, result *domain.SyncResult) error {
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(ctx, result)
+	}
+	return nil
+}
+
+func (m *SyncRepository) Release(ctx context.Context, sessionID string, operationID string) error {
+	if m.ReleaseFunc != nil {
+		return m.ReleaseFunc(ctx, sessionID, operationID)
+	}
+	return nil
+}
+
+func (m *SyncRepository) Get(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, sessionID, operationID)
+	}
+	return nil, nil
+}
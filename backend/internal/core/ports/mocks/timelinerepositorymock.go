@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TimelineRepository = (*TimelineRepository)(nil)
+
+type TimelineRepository struct {
+	SaveFunc        func(ctx context.Context, event *domain.TimelineEvent) error
+	GetByIDFunc     func(ctx context.Context, id string) (*domain.TimelineEvent, error)
+	UpdateFunc      func(ctx context.Context, event *domain.TimelineEvent) error
+	GetByBrewIDFunc func(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error)
+}
+
+func (m *TimelineRepository) Save(ctx context.Context, event *domain.TimelineEvent) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, event)
+	}
+	return nil
+}
+
+func (m *TimelineRepository) GetByID(ctx context.Context, id string) (*domain.TimelineEvent, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *TimelineRepository) Update(ctx context.Context, event *domain.TimelineEvent) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, event)
+	}
+	return nil
+}
+
+func (m *TimelineRepository) GetByBrewID(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error) {
+	if m.GetByBrewIDFunc != nil {
+		return m.GetByBrewIDFunc(ctx, brewID)
+	}
+	return nil, nil
+}
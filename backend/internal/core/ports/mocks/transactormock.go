@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"brew/internal/core/ports"
+)
+
+var _ ports.Transactor = (*Transactor)(nil)
+
+type Transactor struct {
+	WithinTransactionFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+func (m *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.WithinTransactionFunc != nil {
+		return m.WithinTransactionFunc(ctx, fn)
+	}
+	return fn(ctx)
+}
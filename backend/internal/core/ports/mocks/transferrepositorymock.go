@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+)
+
+var _ ports.TransferRepository = (*TransferRepository)(nil)
+
+type TransferRepository struct {
+	SaveFunc       func(ctx context.Context, token *domain.TransferToken) error
+	GetByTokenFunc func(ctx context.Context, token string) (*domain.TransferToken, error)
+	RedeemFunc     func(ctx context.Context, token string, toSessionID string, now time.Time) (*domain.TransferToken, error)
+}
+
+func (m *TransferRepository) Save(ctx context.Context, token *domain.TransferToken) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *TransferRepository) GetByToken(ctx context.Context, token string) (*domain.TransferToken, error) {
+	if m.GetByTokenFunc != nil {
+		return m.GetByTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+func (m *TransferRepository) Redeem(
+	ctx context.Context,
+	token string,
+	toSessionID string,
+	now time.Time,
+) (*domain.TransferToken, error) {
+	if m.RedeemFunc != nil {
+		return m.RedeemFunc(ctx, token, toSessionID, now)
+	}
+	return nil, nil
+}
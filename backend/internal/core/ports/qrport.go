@@ -2,11 +2,21 @@ package ports
 
 import "context"
 
+type QRFormat string
+
+const (
+	QRFormatPNG QRFormat = "png"
+	QRFormatSVG QRFormat = "svg"
+)
+
 type QRCodeGenerator interface {
 	GenerateQRCode(
 		ctx context.Context,
 		brewID string,
+		format QRFormat,
 	) ([]byte, error)
+	// ParseQRCode accepts either an uploaded image of a label or the text
+	// payload already decoded by a scanner, and returns the brew ID.
 	ParseQRCode(
 		ctx context.Context,
 		qrData []byte,
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"brew/internal/core/domain"
 )
@@ -13,6 +14,13 @@ type PaginatedResult[T any] struct {
 	HasMore     bool
 }
 
+// Transactor makes the repository calls fn makes with the context it
+// receives succeed or fail together. Nested calls join the outer
+// transaction; a nested fn that fails undoes only its own writes.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type BrewRepository interface {
 	Save(ctx context.Context, brew *domain.Brew) error
 	GetByID(ctx context.Context, id string) (*domain.Brew, error)
@@ -22,13 +30,131 @@ type BrewRepository interface {
 		pointer *string,
 		limit int,
 	) (*PaginatedResult[*domain.Brew], error)
+	// Query returns the page of a session's jars the query selects; see
+	// domain.BrewQuery for the order. Search is matched as by
+	// domain.MatchesSearch.
+	Query(ctx context.Context, query domain.BrewQuery) (*PaginatedResult[*domain.Brew], error)
+	// Update stores brew only if the stored Version still equals
+	// brew.Version, failing with domain.ErrConflict otherwise, and bumps
+	// brew.Version on success. NextActionAt is left as stored.
 	Update(ctx context.Context, brew *domain.Brew) error
+	// SetNextAction stores when the jar's next action is due. It follows
+	// the timeline rather than an edit of the jar, so Version stays put.
+	SetNextAction(ctx context.Context, id string, at *time.Time) error
 	Exists(ctx context.Context, id string) (bool, error)
 }
 
+type BrewRecordRepository interface {
+	Save(ctx context.Context, record *domain.BrewRecord) error
+	GetByID(ctx context.Context, id string) (*domain.BrewRecord, error)
+	GetByBrewID(
+		ctx context.Context,
+		brewID string,
+		pointer *string,
+		limit int,
+	) (*PaginatedResult[*domain.BrewRecord], error)
+	// Update and Delete fail with domain.ErrImmutable for submitted records.
+	Update(ctx context.Context, record *domain.BrewRecord) error
+	Delete(ctx context.Context, id string) error
+	AppendNote(ctx context.Context, note *domain.RecordNote) error
+	GetNotes(ctx context.Context, recordID string) ([]*domain.RecordNote, error)
+}
+
+type TimelineRepository interface {
+	Save(ctx context.Context, event *domain.TimelineEvent) error
+	GetByID(ctx context.Context, id string) (*domain.TimelineEvent, error)
+	Update(ctx context.Context, event *domain.TimelineEvent) error
+	// GetByBrewID returns the jar's events in chronological order.
+	GetByBrewID(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error)
+}
+
+type QualityRepository interface {
+	Save(ctx context.Context, evaluation *domain.QualityEvaluation) error
+	GetByID(ctx context.Context, id string) (*domain.QualityEvaluation, error)
+	// GetByBrewID returns the jar's evaluations oldest first.
+	GetByBrewID(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error)
+}
+
+type TransferRepository interface {
+	Save(ctx context.Context, token *domain.TransferToken) error
+	GetByToken(ctx context.Context, token string) (*domain.TransferToken, error)
+	// Redeem consumes the token and moves its jar and the jar's records to
+	// toSessionID in one step: either all of it happens or nothing does. A used or expired token, or a jar that changed owner
+	// since the token was issued, fails with domain.ErrForbidden.
+	Redeem(
+		ctx context.Context,
+		token string,
+		toSessionID string,
+		now time.Time,
+	) (*domain.TransferToken, error)
+}
+
+type SyncRepository interface {
+	// Claim stores result as pending unless the session already sent the
+	// operation. A pending claim older than staleBefore is taken over, so an
+	// operation whose applier crashed is not stuck forever. When the claim
+	// is not taken, the stored result is returned with claimed false.
+	Claim(
+		ctx context.Context,
+		result *domain.SyncResult,
+		staleBefore time.Time,
+	) (stored *domain.SyncResult, claimed bool, err error)
+	Complete(ctx context.Context, result *domain.SyncResult) error
+	// Release forgets a pending claim so the operation can be sent again.
+	Release(ctx context.Context, sessionID string, operationID string) error
+	Get(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error)
+}
+
+// SearchRepository is the full-text index over jar names, record
+// ingredients and notes. Services write documents after storing what they
+// describe.
+type SearchRepository interface {
+	// Index stores document, replacing the one of the same Kind and ID.
+	Index(ctx context.Context, document *domain.SearchDocument) error
+	// MoveBrew moves every document of the jar, its name as well as its
+	// records and notes, to sessionID after a transfer.
+	MoveBrew(ctx context.Context, brewID string, sessionID string) error
+	// Search returns up to limit of the session's documents containing
+	// every domain.SearchTerms term of query as a word or the start of
+	// one, best match first.
+	Search(ctx context.Context, sessionID string, query string, limit int) ([]*domain.SearchHit, error)
+}
+
+// AuditRepository is append-only; entries come back oldest first.
+type AuditRepository interface {
+	// Append assigns entry.Seq.
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+	GetByBrewID(
+		ctx context.Context,
+		brewID string,
+		pointer *string,
+		limit int,
+	) (*PaginatedResult[*domain.AuditEntry], error)
+	GetBySessionID(
+		ctx context.Context,
+		sessionID string,
+		pointer *string,
+		limit int,
+	) (*PaginatedResult[*domain.AuditEntry], error)
+	// GetSince returns up to limit of the session's entries with a Seq
+	// above afterSeq, in Seq order.
+	GetSince(ctx context.Context, sessionID string, afterSeq int64, limit int) ([]*domain.AuditEntry, error)
+}
+
 type SessionRepository interface {
 	Save(ctx context.Context, session *domain.Session) error
 	GetByID(ctx context.Context, id string) (*domain.Session, error)
+	// GetByShareToken returns the session that issued the token, whatever
+	// the token's state; callers decide whether it is still usable.
+	GetByShareToken(ctx context.Context, token string) (*domain.Session, error)
+	// GetExpired returns active and inactive sessions alike that are past
+	// their ExpiresAt or were last accessed more than ttl before now.
+	GetExpired(ctx context.Context, now time.Time, ttl time.Duration) ([]*domain.Session, error)
+	// Update is conditional on session.Version just like
+	// BrewRepository.Update.
 	Update(ctx context.Context, session *domain.Session) error
+	// Touch sets LastAccessed without bumping the version, so keeping a
+	// session alive never invalidates a version a client holds.
+	Touch(ctx context.Context, id string, at time.Time) error
 	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+// AuditService writes the edit trail that BrewService and SessionService
+// leave behind, so an owner can see what was changed through shared links.
+// Sync clients read the same trail as their change feed.
+type AuditService struct {
+	auditRepo  ports.AuditRepository
+	transactor ports.Transactor
+	clock      ports.Clock
+}
+
+func NewAuditService(
+	auditRepo ports.AuditRepository,
+	transactor ports.Transactor,
+	clock ports.Clock,
+) *AuditService {
+	return &AuditService{
+		auditRepo:  auditRepo,
+		transactor: transactor,
+		clock:      clock,
+	}
+}
+
+// transact runs fn in one transaction. Edits store themselves and call
+// record within it, so an edit is never kept without its audit entry and
+// the change feed cannot miss it.
+func (s *AuditService) transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.transactor.WithinTransaction(ctx, fn)
+}
+
+// record must run inside transact, together with the edit it describes.
+func (s *AuditService) record(
+	ctx context.Context,
+	operation domain.AuditOperation,
+	sessionID string,
+	brewID string,
+	recordID string,
+) error {
+	entry := &domain.AuditEntry{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		Operation: operation,
+		BrewID:    brewID,
+		RecordID:  recordID,
+		At:        s.clock.Now(),
+	}
+	if grant := ShareGrantFrom(ctx); grant != nil {
+		entry.ShareToken = grant.Token
+	}
+
+	if err := s.auditRepo.Append(ctx, entry); err != nil {
+		logger.Error("Failed to write audit entry", "error", err, "operation", operation, "session_id", sessionID)
+		return err
+	}
+	return nil
+}
+
+func (s *AuditService) listByBrew(
+	ctx context.Context,
+	brewID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	result, err := s.auditRepo.GetByBrewID(ctx, brewID, pointer, limit)
+	if err != nil {
+		logger.Error("Failed to list audit entries", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *AuditService) listBySession(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	result, err := s.auditRepo.GetBySessionID(ctx, sessionID, pointer, limit)
+	if err != nil {
+		logger.Error("Failed to list audit entries", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *AuditService) since(
+	ctx context.Context,
+	sessionID string,
+	afterSeq int64,
+	limit int,
+) ([]*domain.AuditEntry, error) {
+	entries, err := s.auditRepo.GetSince(ctx, sessionID, afterSeq, limit)
+	if err != nil {
+		logger.Error("Failed to list audit entries", "error", err, "session_id", sessionID, "after_seq", afterSeq)
+		return nil, err
+	}
+	return entries, nil
+}
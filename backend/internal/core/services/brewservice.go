@@ -2,28 +2,57 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 
 	"brew/internal/core/domain"
 	"brew/internal/core/ports"
 	"brew/internal/utils/logger"
 )
 
+// maxIdentifierAttempts bounds how many fresh IDs CreateBrew tries when a
+// generated one is already taken.
+const maxIdentifierAttempts = 5
+
+// transferTokenTTL gives the receiving brewer a day to accept a jar.
+const transferTokenTTL = 24 * time.Hour
+
 type BrewService struct {
 	brewRepo      ports.BrewRepository
+	recordRepo    ports.BrewRecordRepository
+	transferRepo  ports.TransferRepository
 	sessionRepo   ports.SessionRepository
 	identifierGen ports.IdentifierGenerator
+	qrService     *QRService
+	auditService  *AuditService
+	searchService *SearchService
+	clock         ports.Clock
 }
 
 func NewBrewService(
 	brewRepo ports.BrewRepository,
+	recordRepo ports.BrewRecordRepository,
+	transferRepo ports.TransferRepository,
 	sessionRepo ports.SessionRepository,
 	identifierGen ports.IdentifierGenerator,
+	qrService *QRService,
+	auditService *AuditService,
+	searchService *SearchService,
+	clock ports.Clock,
 ) *BrewService {
 	return &BrewService{
 		brewRepo:      brewRepo,
+		recordRepo:    recordRepo,
+		transferRepo:  transferRepo,
 		sessionRepo:   sessionRepo,
 		identifierGen: identifierGen,
+		qrService:     qrService,
+		auditService:  auditService,
+		searchService: searchService,
+		clock:         clock,
 	}
 }
 
@@ -34,33 +63,644 @@ func (s *BrewService) CreateBrew(
 ) (*domain.Brew, error) {
 	logger.Debug("Creating brew", "name", name, "session_id", sessionID)
 
-	id, err := s.identifierGen.Generate(ctx, name)
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	name, err := domain.NormalizeBrewName(name)
 	if err != nil {
-		logger.Error("Failed to generate identifier", "error", err, "name", name)
 		return nil, err
 	}
 
-	exists, err := s.brewRepo.Exists(ctx, id)
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		id, err := s.identifierGen.Generate(ctx, name)
+		if err != nil {
+			logger.Error("Failed to generate identifier", "error", err, "name", name)
+			return nil, err
+		}
+
+		exists, err := s.brewRepo.Exists(ctx, id)
+		if err != nil {
+			logger.Error("Failed to check if brew exists", "error", err, "id", id)
+			return nil, err
+		}
+		if exists {
+			if attempt < maxIdentifierAttempts {
+				logger.Debug("Generated brew ID is taken, retrying", "id", id, "attempt", attempt)
+				continue
+			}
+			logger.Error("Brew already exists", "id", id)
+			return nil, fmt.Errorf("brew %s: %w", id, domain.ErrAlreadyExists)
+		}
+
+		brew := domain.NewBrew(id, name, sessionID, s.clock.Now())
+
+		err = s.auditService.transact(ctx, func(ctx context.Context) error {
+			if err := s.brewRepo.Save(ctx, brew); err != nil {
+				return err
+			}
+			s.searchService.index(ctx, domain.NewBrewSearchDocument(brew))
+			return s.auditService.record(ctx, domain.AuditBrewCreated, sessionID, id, "")
+		})
+		if errors.Is(err, domain.ErrAlreadyExists) && attempt < maxIdentifierAttempts {
+			// Another request took the ID between Exists and Save.
+			logger.Debug("Generated brew ID was taken concurrently, retrying", "id", id, "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to save brew", "error", err, "id", id)
+			return nil, err
+		}
+
+		logger.Debug("Brew created successfully", "id", id, "name", name)
+		return brew, nil
+	}
+}
+
+func (s *BrewService) GetBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+) (*domain.Brew, error) {
+	logger.Debug("Getting brew by ID", "id", id, "session_id", sessionID)
+
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	brew, err := s.brewRepo.GetByID(ctx, id)
 	if err != nil {
-		logger.Error("Failed to check if brew exists", "error", err, "id", id)
 		return nil, err
 	}
-	if exists {
-		logger.Error("Brew already exists", "id", id)
-		return nil, fmt.Errorf("brew with id %s already exists", id)
+	if err := authorizeBrew(brew, sessionID); err != nil {
+		return nil, err
+	}
+	return brew, nil
+}
+
+// RenameBrew renames the jar. A non-nil expectedVersion is the Version the
+// caller last saw; if the jar changed since, the rename fails with
+// domain.ErrConflict instead of silently overwriting that change.
+func (s *BrewService) RenameBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+	name string,
+	expectedVersion *int64,
+) (*domain.Brew, error) {
+	logger.Debug("Renaming brew", "id", id, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	name, err := domain.NormalizeBrewName(name)
+	if err != nil {
+		return nil, err
 	}
 
-	brew := &domain.Brew{
-		ID:   id,
-		Name: name,
+	return s.editBrew(ctx, id, sessionID, expectedVersion, domain.AuditBrewRenamed, func(brew *domain.Brew, now time.Time) bool {
+		brew.UpdateName(name, now)
+		return true
+	})
+}
+
+// UpdateBrew changes the jar's name and details such as vessel volume,
+// location, colour tag and SCOBY origin; expectedVersion works as in
+// RenameBrew.
+func (s *BrewService) UpdateBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+	changes domain.BrewChanges,
+	expectedVersion *int64,
+) (*domain.Brew, error) {
+	logger.Debug("Updating brew", "id", id, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if changes.IsEmpty() {
+		return nil, fmt.Errorf("no jar details to change: %w", domain.ErrInvalid)
+	}
+	changes = changes.Normalize()
+	if err := changes.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.editBrew(ctx, id, sessionID, expectedVersion, domain.AuditBrewUpdated, func(brew *domain.Brew, now time.Time) bool {
+		brew.Apply(changes, now)
+		return true
+	})
+}
+
+// ArchiveBrew retires the jar; archiving an archived jar changes nothing.
+func (s *BrewService) ArchiveBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+	expectedVersion *int64,
+) (*domain.Brew, error) {
+	logger.Debug("Archiving brew", "id", id, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	return s.editBrew(ctx, id, sessionID, expectedVersion, domain.AuditBrewArchived, (*domain.Brew).Archive)
+}
+
+// UnarchiveBrew brings an archived jar back.
+func (s *BrewService) UnarchiveBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+	expectedVersion *int64,
+) (*domain.Brew, error) {
+	logger.Debug("Unarchiving brew", "id", id, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	return s.editBrew(ctx, id, sessionID, expectedVersion, domain.AuditBrewUnarchived, (*domain.Brew).Unarchive)
+}
+
+// editBrew applies edit to the jar owned by sessionID and stores it unless
+// edit reports that nothing changed.
+func (s *BrewService) editBrew(
+	ctx context.Context,
+	id string,
+	sessionID string,
+	expectedVersion *int64,
+	operation domain.AuditOperation,
+	edit func(brew *domain.Brew, now time.Time) bool,
+) (*domain.Brew, error) {
+	brew, err := s.GetBrew(ctx, id, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != nil && *expectedVersion != brew.Version {
+		return nil, fmt.Errorf("brew %s is at version %d, not %d: %w", id, brew.Version, *expectedVersion, domain.ErrConflict)
 	}
 
-	err = s.brewRepo.Save(ctx, brew)
+	if !edit(brew, s.clock.Now()) {
+		return brew, nil
+	}
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.brewRepo.Update(ctx, brew); err != nil {
+			return err
+		}
+		s.searchService.index(ctx, domain.NewBrewSearchDocument(brew))
+		return s.auditService.record(ctx, operation, sessionID, id, "")
+	})
 	if err != nil {
-		logger.Error("Failed to save brew", "error", err, "id", id)
+		logger.Error("Failed to update brew", "error", err, "id", id, "operation", operation)
 		return nil, err
 	}
 
-	logger.Debug("Brew created successfully", "id", id, "name", name)
+	logger.Debug("Brew updated successfully", "id", id, "operation", operation, "version", brew.Version)
 	return brew, nil
 }
+
+// LookupBrew resolves whatever the brewer has at hand (a label photo, a
+// scanned deep link or a hand-typed code) to the jar and its history.
+func (s *BrewService) LookupBrew(
+	ctx context.Context,
+	input []byte,
+	sessionID string,
+) (*domain.BrewDetails, error) {
+	logger.Debug("Looking up brew", "session_id", sessionID, "data_size", len(input))
+
+	parsed, err := s.qrService.ParseQRCode(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	brew, err := s.resolveBrew(ctx, parsed, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.recordRepo.GetByBrewID(ctx, brew.ID, nil, 0)
+	if err != nil {
+		logger.Error("Failed to list brew records", "error", err, "brew_id", brew.ID)
+		return nil, err
+	}
+
+	details := &domain.BrewDetails{
+		Brew:    brew,
+		History: history.Items,
+	}
+	if len(history.Items) > 0 {
+		latest := history.Items[len(history.Items)-1]
+		notes, err := s.recordRepo.GetNotes(ctx, latest.ID)
+		if err != nil {
+			logger.Error("Failed to get record notes", "error", err, "id", latest.ID)
+			return nil, err
+		}
+		details.LatestRecord = &domain.AnnotatedRecord{Record: latest, Notes: notes}
+	}
+
+	logger.Debug("Brew looked up successfully", "id", brew.ID, "records", len(history.Items))
+	return details, nil
+}
+
+// resolveBrew prefers the canonical form of a typed code, but still finds
+// jars whose IDs predate the current identifier format.
+func (s *BrewService) resolveBrew(
+	ctx context.Context,
+	identifier string,
+	sessionID string,
+) (*domain.Brew, error) {
+	canonical, normalizeErr := s.identifierGen.Normalize(ctx, identifier)
+	if normalizeErr == nil {
+		return s.GetBrew(ctx, canonical, sessionID)
+	}
+
+	brew, err := s.GetBrew(ctx, identifier, sessionID)
+	if errors.Is(err, domain.ErrNotFound) {
+		logger.Debug("Identifier is neither valid nor known", "identifier", identifier, "error", normalizeErr)
+		return nil, normalizeErr
+	}
+	return brew, err
+}
+
+func (s *BrewService) ListBrews(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.Brew], error) {
+	logger.Debug("Listing brews", "session_id", sessionID, "limit", limit)
+
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.brewRepo.GetBySessionID(ctx, sessionID, pointer, limit)
+	if err != nil {
+		logger.Error("Failed to list brews", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	logger.Debug("Brews listed successfully", "session_id", sessionID, "count", len(result.Items))
+	return result, nil
+}
+
+// QueryBrews lists the session's jars filtered, searched and sorted as the
+// query says; the zero query lists every jar oldest first.
+func (s *BrewService) QueryBrews(
+	ctx context.Context,
+	query domain.BrewQuery,
+) (*ports.PaginatedResult[*domain.Brew], error) {
+	logger.Debug("Querying brews", "session_id", query.SessionID, "status", query.Status, "sort", query.Sort, "limit", query.Limit)
+
+	query = query.Normalize()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActiveSession(ctx, query.SessionID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.brewRepo.Query(ctx, query)
+	if err != nil {
+		logger.Error("Failed to query brews", "error", err, "session_id", query.SessionID)
+		return nil, err
+	}
+
+	logger.Debug("Brews queried successfully", "session_id", query.SessionID, "count", len(result.Items))
+	return result, nil
+}
+
+// Search finds the session's jars, records and notes by their words, e.g.
+// "hibiscus" for every batch brewed with it, best match first.
+func (s *BrewService) Search(
+	ctx context.Context,
+	sessionID string,
+	search string,
+	limit int,
+) ([]*domain.SearchHit, error) {
+	logger.Debug("Searching brews", "session_id", sessionID, "limit", limit)
+
+	search, err := domain.NormalizeSearch(search)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	hits, err := s.searchService.search(ctx, sessionID, search, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("Brews searched successfully", "session_id", sessionID, "count", len(hits))
+	return hits, nil
+}
+
+func (s *BrewService) AddRecord(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+	recipe domain.Recipe,
+) (*domain.BrewRecord, error) {
+	logger.Debug("Adding brew record", "brew_id", brewID, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	recipe = recipe.Normalize()
+	if err := recipe.Validate(); err != nil {
+		return nil, err
+	}
+
+	brew, err := s.GetBrew(ctx, brewID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	record := domain.NewBrewRecord(uuid.NewString(), brewID, sessionID, recipe, now)
+	record.Submit(now)
+
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.recordRepo.Save(ctx, record); err != nil {
+			return err
+		}
+		s.searchService.index(ctx, domain.NewRecordSearchDocument(brew, record))
+		return s.auditService.record(ctx, domain.AuditRecordAdded, sessionID, brewID, record.ID)
+	})
+	if err != nil {
+		logger.Error("Failed to save brew record", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+
+	logger.Debug("Brew record added successfully", "id", record.ID, "brew_id", brewID)
+	return record, nil
+}
+
+func (s *BrewService) ListRecords(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.BrewRecord], error) {
+	logger.Debug("Listing brew records", "brew_id", brewID, "session_id", sessionID, "limit", limit)
+
+	if _, err := s.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.recordRepo.GetByBrewID(ctx, brewID, pointer, limit)
+	if err != nil {
+		logger.Error("Failed to list brew records", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+
+	logger.Debug("Brew records listed successfully", "brew_id", brewID, "count", len(result.Items))
+	return result, nil
+}
+
+func (s *BrewService) GetRecord(
+	ctx context.Context,
+	recordID string,
+	sessionID string,
+) (*domain.AnnotatedRecord, error) {
+	logger.Debug("Getting brew record", "id", recordID, "session_id", sessionID)
+
+	record, err := s.recordRepo.GetByID(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetBrew(ctx, record.BrewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.recordRepo.GetNotes(ctx, recordID)
+	if err != nil {
+		logger.Error("Failed to get record notes", "error", err, "id", recordID)
+		return nil, err
+	}
+
+	return &domain.AnnotatedRecord{
+		Record: record,
+		Notes:  notes,
+	}, nil
+}
+
+func (s *BrewService) AppendNote(
+	ctx context.Context,
+	recordID string,
+	sessionID string,
+	text string,
+) (*domain.RecordNote, error) {
+	logger.Debug("Appending record note", "record_id", recordID, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	invalid := &domain.ValidationError{}
+	text = domain.ValidateText(invalid, "text", text, true)
+	if err := invalid.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	record, err := s.recordRepo.GetByID(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+	brew, err := s.GetBrew(ctx, record.BrewID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := domain.NewRecordNote(uuid.NewString(), recordID, sessionID, text, s.clock.Now())
+
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.recordRepo.AppendNote(ctx, note); err != nil {
+			return err
+		}
+		s.searchService.index(ctx, domain.NewNoteSearchDocument(brew, record, note))
+		return s.auditService.record(ctx, domain.AuditNoteAppended, sessionID, record.BrewID, recordID)
+	})
+	if err != nil {
+		logger.Error("Failed to append record note", "error", err, "record_id", recordID)
+		return nil, err
+	}
+
+	logger.Debug("Record note appended successfully", "id", note.ID, "record_id", recordID)
+	return note, nil
+}
+
+// OfferTransfer issues a one-time token another session can redeem with
+// AcceptTransfer to take the jar over.
+func (s *BrewService) OfferTransfer(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+) (*domain.TransferToken, error) {
+	logger.Debug("Offering brew transfer", "brew_id", brewID, "session_id", sessionID)
+
+	if err := requireOwner(ctx, "offer a jar for transfer"); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	value, err := newRandomToken()
+	if err != nil {
+		logger.Error("Failed to generate transfer token", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	transfer := &domain.TransferToken{
+		Token:         value,
+		BrewID:        brewID,
+		FromSessionID: sessionID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(transferTokenTTL),
+	}
+
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.transferRepo.Save(ctx, transfer); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditTransferOffered, sessionID, brewID, "")
+	})
+	if err != nil {
+		logger.Error("Failed to save transfer token", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+
+	logger.Debug("Brew transfer offered successfully", "brew_id", brewID)
+	return transfer, nil
+}
+
+// AcceptTransfer moves the jar behind the token, records included, into
+// sessionID. The old session loses access at once, while the jar's audit
+// trail moves with it.
+func (s *BrewService) AcceptTransfer(
+	ctx context.Context,
+	token string,
+	sessionID string,
+) (*domain.Brew, error) {
+	logger.Debug("Accepting brew transfer", "session_id", sessionID)
+
+	if err := requireOwner(ctx, "accept a jar transfer"); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	offered, err := s.transferRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if offered.FromSessionID == sessionID {
+		return nil, fmt.Errorf("brew %s already belongs to session %s: %w", offered.BrewID, sessionID, domain.ErrInvalid)
+	}
+	// A jar of a reaped session stays with it even if it was on offer.
+	from, err := s.sessionRepo.GetByID(ctx, offered.FromSessionID)
+	if err != nil {
+		logger.Error("Failed to get offering session", "error", err, "session_id", offered.FromSessionID)
+		return nil, err
+	}
+	if !from.IsActive {
+		logger.Debug("Transfer offered by an inactive session", "brew_id", offered.BrewID, "from", offered.FromSessionID)
+		return nil, fmt.Errorf("offering session has expired: %w", domain.ErrForbidden)
+	}
+
+	var transfer *domain.TransferToken
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.transferRepo.Redeem(ctx, token, sessionID, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		s.searchService.moveBrew(ctx, transfer.BrewID, sessionID)
+		if err := s.auditService.record(ctx, domain.AuditBrewTransferredOut, transfer.FromSessionID, transfer.BrewID, ""); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditBrewTransferredIn, sessionID, transfer.BrewID, "")
+	})
+	if err != nil {
+		logger.Error("Failed to redeem transfer token", "error", err, "brew_id", offered.BrewID)
+		return nil, err
+	}
+
+	logger.Debug("Brew transferred successfully", "brew_id", transfer.BrewID, "from", transfer.FromSessionID, "to", sessionID)
+	return s.GetBrew(ctx, transfer.BrewID, sessionID)
+}
+
+// ListAuditEntries shows the owner who changed the jar and how. Entries
+// written by other sessions, such as a previous owner before a transfer,
+// are redacted so their credentials don't reach the current owner.
+func (s *BrewService) ListAuditEntries(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	logger.Debug("Listing brew audit entries", "brew_id", brewID, "session_id", sessionID, "limit", limit)
+
+	if err := requireOwner(ctx, "read the audit log"); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.auditService.listByBrew(ctx, brewID, pointer, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range result.Items {
+		if entry.SessionID != sessionID {
+			entry.Redact()
+		}
+	}
+	return result, nil
+}
+
+func (s *BrewService) requireActiveSession(
+	ctx context.Context,
+	sessionID string,
+) (*domain.Session, error) {
+	if err := requireReadAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		logger.Error("Failed to get session", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+	if !session.IsActive {
+		logger.Debug("Session is inactive", "session_id", sessionID)
+		return nil, fmt.Errorf("session %s: %w", sessionID, domain.ErrSessionInactive)
+	}
+	return session, nil
+}
+
+// authorizeBrew keeps jars isolated to the session that created them. A
+// foreign jar is reported exactly like a missing one, so its ID can't be
+// probed for existence.
+func authorizeBrew(brew *domain.Brew, sessionID string) error {
+	if brew.SessionID != sessionID {
+		logger.Debug("Brew belongs to another session", "id", brew.ID, "session_id", sessionID)
+		return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrNotFound)
+	}
+	return nil
+}
@@ -3,12 +3,31 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"brew/internal/core/domain"
+	"brew/internal/core/ports"
 	"brew/internal/core/ports/mocks"
 )
 
+// testNow is what the services read from the clock in tests.
+var testNow = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestClock() *mocks.Clock {
+	return &mocks.Clock{NowFunc: func() time.Time { return testNow }}
+}
+
+func newActiveSessionRepository() *mocks.SessionRepository {
+	return &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			return &domain.Session{ID: id, IsActive: true}, nil
+		},
+	}
+}
+
 func TestBrewService_CreateBrew_Success(t *testing.T) {
 	var receivedName string
 	var receivedExistsID string
@@ -24,7 +43,7 @@ func TestBrewService_CreateBrew_Success(t *testing.T) {
 			return nil
 		},
 	}
-	sessionRepo := &mocks.SessionRepository{}
+	sessionRepo := newActiveSessionRepository()
 	identifierGen := &mocks.IdentifierGenerator{
 		GenerateFunc: func(
 			ctx context.Context,
@@ -35,7 +54,17 @@ func TestBrewService_CreateBrew_Success(t *testing.T) {
 		},
 	}
 
-	service := NewBrewService(brewRepo, sessionRepo, identifierGen)
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
 
 	ctx := context.Background()
 	name := "test-brew"
@@ -55,6 +84,9 @@ func TestBrewService_CreateBrew_Success(t *testing.T) {
 	if brew.Name != name {
 		t.Fatalf("CreateBrew() brew.Name = %v, want %v", brew.Name, name)
 	}
+	if !brew.CreatedAt.Equal(testNow) || !brew.UpdatedAt.Equal(testNow) {
+		t.Fatalf("CreateBrew() timestamps = %v/%v, want %v", brew.CreatedAt, brew.UpdatedAt, testNow)
+	}
 
 	if receivedName != name {
 		t.Fatalf("Generate called with name = %v, want %v", receivedName, name)
@@ -89,7 +121,7 @@ func TestBrewService_CreateBrew_IdentifierGenerationError(t *testing.T) {
 			return nil
 		},
 	}
-	sessionRepo := &mocks.SessionRepository{}
+	sessionRepo := newActiveSessionRepository()
 	identifierGen := &mocks.IdentifierGenerator{
 		GenerateFunc: func(
 			ctx context.Context,
@@ -99,7 +131,17 @@ func TestBrewService_CreateBrew_IdentifierGenerationError(t *testing.T) {
 		},
 	}
 
-	service := NewBrewService(brewRepo, sessionRepo, identifierGen)
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
 
 	ctx := context.Background()
 	name := "test-brew"
@@ -124,7 +166,7 @@ func TestBrewService_CreateBrew_BrewAlreadyExists(t *testing.T) {
 			return true, nil
 		},
 	}
-	sessionRepo := &mocks.SessionRepository{}
+	sessionRepo := newActiveSessionRepository()
 	identifierGen := &mocks.IdentifierGenerator{
 		GenerateFunc: func(
 			ctx context.Context,
@@ -134,7 +176,17 @@ func TestBrewService_CreateBrew_BrewAlreadyExists(t *testing.T) {
 		},
 	}
 
-	service := NewBrewService(brewRepo, sessionRepo, identifierGen)
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
 
 	ctx := context.Background()
 	name := "test-brew"
@@ -148,9 +200,94 @@ func TestBrewService_CreateBrew_BrewAlreadyExists(t *testing.T) {
 	if brew != nil {
 		t.Fatal("CreateBrew() returned brew, want nil")
 	}
-	expectedError := "brew with id brew-123 already exists"
-	if err.Error() != expectedError {
-		t.Fatalf("CreateBrew() error = %v, want %v", err.Error(), expectedError)
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Fatalf("CreateBrew() error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestBrewService_CreateBrew_RetriesTakenIdentifier(t *testing.T) {
+	var savedID string
+	brewRepo := &mocks.BrewRepository{
+		ExistsFunc: func(ctx context.Context, id string) (bool, error) {
+			return id != "brew-3", nil
+		},
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			savedID = brew.ID
+			return nil
+		},
+	}
+	generated := 0
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			generated++
+			return fmt.Sprintf("brew-%d", generated), nil
+		},
+	}
+
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "test-brew", "session-123")
+	if err != nil {
+		t.Fatalf("CreateBrew() error = %v, want nil", err)
+	}
+	if brew.ID != "brew-3" || savedID != "brew-3" {
+		t.Fatalf("CreateBrew() ID = %s, saved %s, want brew-3", brew.ID, savedID)
+	}
+	if generated != 3 {
+		t.Fatalf("Generate() called %d times, want 3", generated)
+	}
+}
+
+func TestBrewService_CreateBrew_RetriesConcurrentSaveConflict(t *testing.T) {
+	saves := 0
+	brewRepo := &mocks.BrewRepository{
+		ExistsFunc: func(ctx context.Context, id string) (bool, error) {
+			return false, nil
+		},
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			saves++
+			if saves == 1 {
+				return fmt.Errorf("brew %s: %w", brew.ID, domain.ErrAlreadyExists)
+			}
+			return nil
+		},
+	}
+	generated := 0
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			generated++
+			return fmt.Sprintf("brew-%d", generated), nil
+		},
+	}
+
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "test-brew", "session-123")
+	if err != nil {
+		t.Fatalf("CreateBrew() error = %v, want nil", err)
+	}
+	if brew.ID != "brew-2" {
+		t.Fatalf("CreateBrew() ID = %s, want brew-2", brew.ID)
 	}
 }
 
@@ -160,7 +297,7 @@ func TestBrewService_CreateBrew_ExistsCheckError(t *testing.T) {
 			return false, errors.New("exists check failed")
 		},
 	}
-	sessionRepo := &mocks.SessionRepository{}
+	sessionRepo := newActiveSessionRepository()
 	identifierGen := &mocks.IdentifierGenerator{
 		GenerateFunc: func(
 			ctx context.Context,
@@ -170,7 +307,17 @@ func TestBrewService_CreateBrew_ExistsCheckError(t *testing.T) {
 		},
 	}
 
-	service := NewBrewService(brewRepo, sessionRepo, identifierGen)
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
 
 	ctx := context.Background()
 	name := "test-brew"
@@ -198,7 +345,7 @@ func TestBrewService_CreateBrew_SaveError(t *testing.T) {
 			return errors.New("save failed")
 		},
 	}
-	sessionRepo := &mocks.SessionRepository{}
+	sessionRepo := newActiveSessionRepository()
 	identifierGen := &mocks.IdentifierGenerator{
 		GenerateFunc: func(
 			ctx context.Context,
@@ -208,7 +355,17 @@ func TestBrewService_CreateBrew_SaveError(t *testing.T) {
 		},
 	}
 
-	service := NewBrewService(brewRepo, sessionRepo, identifierGen)
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
 
 	ctx := context.Background()
 	name := "test-brew"
@@ -226,3 +383,1221 @@ func TestBrewService_CreateBrew_SaveError(t *testing.T) {
 		t.Fatal("CreateBrew() returned brew, want nil")
 	}
 }
+
+func TestBrewService_ListBrews_Success(t *testing.T) {
+	var receivedSessionID string
+	var receivedLimit int
+
+	brewRepo := &mocks.BrewRepository{
+		GetBySessionIDFunc: func(
+			ctx context.Context,
+			sessionID string,
+			pointer *string,
+			limit int,
+		) (*ports.PaginatedResult[*domain.Brew], error) {
+			receivedSessionID = sessionID
+			receivedLimit = limit
+			return &ports.PaginatedResult[*domain.Brew]{
+				Items:      []*domain.Brew{{ID: "brew-123", SessionID: sessionID}},
+				TotalCount: 1,
+			}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	result, err := service.ListBrews(context.Background(), "session-123", nil, 10)
+
+	if err != nil {
+		t.Fatalf("ListBrews() error = %v, want nil", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].ID != "brew-123" {
+		t.Fatalf("ListBrews() items = %v, want [brew-123]", result.Items)
+	}
+	if receivedSessionID != "session-123" {
+		t.Fatalf("GetBySessionID called with sessionID = %v, want session-123", receivedSessionID)
+	}
+	if receivedLimit != 10 {
+		t.Fatalf("GetBySessionID called with limit = %v, want 10", receivedLimit)
+	}
+}
+
+func TestBrewService_ListBrews_RepositoryError(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetBySessionIDFunc: func(
+			ctx context.Context,
+			sessionID string,
+			pointer *string,
+			limit int,
+		) (*ports.PaginatedResult[*domain.Brew], error) {
+			return nil, errors.New("list failed")
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	result, err := service.ListBrews(context.Background(), "session-123", nil, 10)
+
+	if err == nil {
+		t.Fatal("ListBrews() error = nil, want error")
+	}
+	if result != nil {
+		t.Fatal("ListBrews() returned result, want nil")
+	}
+}
+
+func TestBrewService_QueryBrews_RejectsUnknownOptions(t *testing.T) {
+	queried := false
+	brewRepo := &mocks.BrewRepository{
+		QueryFunc: func(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error) {
+			queried = true
+			return &ports.PaginatedResult[*domain.Brew]{}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	_, err := service.QueryBrews(context.Background(), domain.BrewQuery{
+		SessionID: "session-123",
+		Status:    "deleted",
+		Sort:      "name",
+		Search:    strings.Repeat("a", domain.MaxSearchLength+1),
+	})
+
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Fields) != 3 {
+		t.Fatalf("QueryBrews() error = %v, want search, status and sort field errors", err)
+	}
+	if queried {
+		t.Fatal("QueryBrews() queried the repository with invalid options")
+	}
+}
+
+func TestBrewService_QueryBrews_DefaultsToOldestFirst(t *testing.T) {
+	var received domain.BrewQuery
+	brewRepo := &mocks.BrewRepository{
+		QueryFunc: func(ctx context.Context, query domain.BrewQuery) (*ports.PaginatedResult[*domain.Brew], error) {
+			received = query
+			return &ports.PaginatedResult[*domain.Brew]{}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	if _, err := service.QueryBrews(context.Background(), domain.BrewQuery{SessionID: "session-123", Search: "  чайний \t гриб "}); err != nil {
+		t.Fatalf("QueryBrews() error = %v, want nil", err)
+	}
+	if received.Sort != domain.SortByCreated || received.Search != "чайний гриб" {
+		t.Fatalf("Query called with %+v, want created sort and a normalized search", received)
+	}
+}
+
+func TestBrewService_CreateBrew_StoresSessionID(t *testing.T) {
+	var receivedSaveBrew *domain.Brew
+
+	brewRepo := &mocks.BrewRepository{
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			receivedSaveBrew = brew
+			return nil
+		},
+	}
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			return "brew-123", nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "test-brew", "session-123")
+
+	if err != nil {
+		t.Fatalf("CreateBrew() error = %v, want nil", err)
+	}
+	if brew.SessionID != "session-123" {
+		t.Fatalf("CreateBrew() brew.SessionID = %v, want session-123", brew.SessionID)
+	}
+	if receivedSaveBrew == nil || receivedSaveBrew.SessionID != "session-123" {
+		t.Fatalf("Save called with brew = %+v, want session-123 owner", receivedSaveBrew)
+	}
+}
+
+func TestBrewService_CreateBrew_SessionNotFound(t *testing.T) {
+	generateCalled := false
+
+	sessionRepo := &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			generateCalled = true
+			return "brew-123", nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "test-brew", "session-123")
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("CreateBrew() error = %v, want ErrNotFound", err)
+	}
+	if brew != nil {
+		t.Fatal("CreateBrew() returned brew, want nil")
+	}
+	if generateCalled {
+		t.Fatal("Generate called for unknown session")
+	}
+}
+
+func TestBrewService_CreateBrew_SessionInactive(t *testing.T) {
+	sessionRepo := &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			return &domain.Session{ID: id, IsActive: false}, nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		sessionRepo,
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "test-brew", "session-123")
+
+	if !errors.Is(err, domain.ErrSessionInactive) {
+		t.Fatalf("CreateBrew() error = %v, want ErrSessionInactive", err)
+	}
+	if brew != nil {
+		t.Fatal("CreateBrew() returned brew, want nil")
+	}
+}
+
+func TestBrewService_GetBrew_OwnSession(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.GetBrew(context.Background(), "brew-123", "session-123")
+
+	if err != nil {
+		t.Fatalf("GetBrew() error = %v, want nil", err)
+	}
+	if brew.ID != "brew-123" {
+		t.Fatalf("GetBrew() brew.ID = %v, want brew-123", brew.ID)
+	}
+}
+
+func TestBrewService_GetBrew_OtherSessionNotFound(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-owner"}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.GetBrew(context.Background(), "brew-123", "session-other")
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("GetBrew() error = %v, want ErrNotFound", err)
+	}
+	if brew != nil {
+		t.Fatal("GetBrew() returned brew, want nil")
+	}
+}
+
+func TestBrewService_ReadsRefuseReapedSession(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			return &domain.BrewRecord{ID: id, BrewID: "brew-123"}, nil
+		},
+	}
+	sessionRepo := &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			session := &domain.Session{ID: id, IsActive: true}
+			session.Purge()
+			return session, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		sessionRepo,
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	ctx := context.Background()
+
+	if _, err := service.GetBrew(ctx, "brew-123", "session-123"); !errors.Is(err, domain.ErrSessionInactive) {
+		t.Errorf("GetBrew() error = %v, want ErrSessionInactive", err)
+	}
+	if _, err := service.ListRecords(ctx, "brew-123", "session-123", nil, 0); !errors.Is(err, domain.ErrSessionInactive) {
+		t.Errorf("ListRecords() error = %v, want ErrSessionInactive", err)
+	}
+	if _, err := service.GetRecord(ctx, "record-1", "session-123"); !errors.Is(err, domain.ErrSessionInactive) {
+		t.Errorf("GetRecord() error = %v, want ErrSessionInactive", err)
+	}
+}
+
+func TestBrewService_AcceptTransfer_FromReapedSession(t *testing.T) {
+	transferRepo := &mocks.TransferRepository{
+		GetByTokenFunc: func(ctx context.Context, token string) (*domain.TransferToken, error) {
+			return &domain.TransferToken{Token: token, BrewID: "brew-123", FromSessionID: "reaped"}, nil
+		},
+		RedeemFunc: func(ctx context.Context, token string, toSessionID string, now time.Time) (*domain.TransferToken, error) {
+			t.Error("Redeem called for a jar of a reaped session")
+			return nil, domain.ErrForbidden
+		},
+	}
+	sessionRepo := &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			return &domain.Session{ID: id, IsActive: id != "reaped"}, nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		&mocks.BrewRecordRepository{},
+		transferRepo,
+		sessionRepo,
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	if _, err := service.AcceptTransfer(context.Background(), "token-1", "session-new"); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("AcceptTransfer() error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestBrewService_RenameBrew_ChecksVersion(t *testing.T) {
+	var updated *domain.Brew
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, Name: "before", SessionID: "session-123", Version: 3}, nil
+		},
+		UpdateFunc: func(ctx context.Context, brew *domain.Brew) error {
+			updated = brew
+			brew.Version++
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	ctx := context.Background()
+
+	stale := int64(2)
+	if _, err := service.RenameBrew(ctx, "brew-123", "session-123", "after", &stale); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("RenameBrew() with a stale version error = %v, want ErrConflict", err)
+	}
+	if updated != nil {
+		t.Fatal("RenameBrew() with a stale version updated the brew")
+	}
+
+	if _, err := service.RenameBrew(ctx, "brew-123", "session-123", "  ", nil); !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("RenameBrew() with a blank name error = %v, want ErrInvalid", err)
+	}
+
+	current := int64(3)
+	brew, err := service.RenameBrew(ctx, "brew-123", "session-123", "after", &current)
+	if err != nil {
+		t.Fatalf("RenameBrew() error = %v", err)
+	}
+	if updated != brew || brew.Name != "after" || brew.Version != 4 {
+		t.Fatalf("RenameBrew() = %+v, want the renamed brew at version 4", brew)
+	}
+}
+
+func TestBrewService_UpdateBrew_KeepsUnsetDetails(t *testing.T) {
+	before := testNow.Add(-time.Hour)
+	var updated *domain.Brew
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{
+				ID:          id,
+				Name:        "Скубі",
+				SessionID:   "session-123",
+				Location:    "кухня",
+				ScobyOrigin: "from the market",
+				UpdatedAt:   before,
+			}, nil
+		},
+		UpdateFunc: func(ctx context.Context, brew *domain.Brew) error {
+			updated = brew
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	ctx := context.Background()
+
+	location := "  балкон "
+	tag := domain.BlueTag
+	volume := domain.Quantity{Amount: 3, Unit: domain.Liters}
+	brew, err := service.UpdateBrew(ctx, "brew-123", "session-123", domain.BrewChanges{
+		VesselVolume: &volume,
+		Location:     &location,
+		ColorTag:     &tag,
+	}, nil)
+	if err != nil {
+		t.Fatalf("UpdateBrew() error = %v", err)
+	}
+	if updated != brew || brew.Name != "Скубі" || brew.ScobyOrigin != "from the market" {
+		t.Fatalf("UpdateBrew() = %+v, want name and SCOBY origin kept", brew)
+	}
+	if brew.Location != "балкон" || brew.ColorTag != domain.BlueTag || brew.VesselVolume != volume {
+		t.Fatalf("UpdateBrew() = %+v, want the new details", brew)
+	}
+	if !brew.UpdatedAt.Equal(testNow) {
+		t.Fatalf("UpdateBrew() UpdatedAt = %v, want %v", brew.UpdatedAt, testNow)
+	}
+
+	unknown := domain.ColorTag("teal")
+	mass := domain.Quantity{Amount: 1, Unit: domain.Kilograms}
+	_, err = service.UpdateBrew(ctx, "brew-123", "session-123", domain.BrewChanges{ColorTag: &unknown, VesselVolume: &mass}, nil)
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Fields) != 2 {
+		t.Fatalf("UpdateBrew() with bad details error = %v, want two field errors", err)
+	}
+	if _, err := service.UpdateBrew(ctx, "brew-123", "session-123", domain.BrewChanges{}, nil); !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("UpdateBrew() without changes error = %v, want ErrInvalid", err)
+	}
+	if _, err := service.UpdateBrew(ctx, "brew-123", "session-other", domain.BrewChanges{Location: &location}, nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("UpdateBrew() of a foreign jar error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBrewService_ArchiveBrew(t *testing.T) {
+	stored := &domain.Brew{ID: "brew-123", SessionID: "session-123"}
+	updates := 0
+	var operations []domain.AuditOperation
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			clone := *stored
+			return &clone, nil
+		},
+		UpdateFunc: func(ctx context.Context, brew *domain.Brew) error {
+			updates++
+			brew.Version++
+			clone := *brew
+			stored = &clone
+			return nil
+		},
+	}
+	auditRepo := &mocks.AuditRepository{
+		AppendFunc: func(ctx context.Context, entry *domain.AuditEntry) error {
+			operations = append(operations, entry.Operation)
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(auditRepo, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	ctx := context.Background()
+
+	brew, err := service.ArchiveBrew(ctx, "brew-123", "session-123", nil)
+	if err != nil || !brew.IsArchived() {
+		t.Fatalf("ArchiveBrew() = %+v, %v, want an archived brew", brew, err)
+	}
+	if _, err := service.ArchiveBrew(ctx, "brew-123", "session-123", nil); err != nil {
+		t.Fatalf("ArchiveBrew() again error = %v", err)
+	}
+	if updates != 1 {
+		t.Fatalf("ArchiveBrew() twice stored %d updates, want 1", updates)
+	}
+
+	stale := int64(0)
+	if _, err := service.UnarchiveBrew(ctx, "brew-123", "session-123", &stale); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("UnarchiveBrew() with a stale version error = %v, want ErrConflict", err)
+	}
+	brew, err = service.UnarchiveBrew(ctx, "brew-123", "session-123", nil)
+	if err != nil || brew.IsArchived() {
+		t.Fatalf("UnarchiveBrew() = %+v, %v, want an active brew", brew, err)
+	}
+	if _, err := service.ArchiveBrew(ctx, "brew-123", "session-other", nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("ArchiveBrew() of a foreign jar error = %v, want ErrNotFound", err)
+	}
+
+	want := []domain.AuditOperation{domain.AuditBrewArchived, domain.AuditBrewUnarchived}
+	if len(operations) != len(want) || operations[0] != want[0] || operations[1] != want[1] {
+		t.Fatalf("audit operations = %v, want %v", operations, want)
+	}
+}
+
+func TestBrewService_AddRecord_Success(t *testing.T) {
+	var receivedRecord *domain.BrewRecord
+
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		SaveFunc: func(ctx context.Context, record *domain.BrewRecord) error {
+			receivedRecord = record
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	recipe := domain.Recipe{
+		Water:     domain.Quantity{Amount: 3, Unit: domain.Liters},
+		SugarType: domain.WhiteSugar,
+		Sugar:     domain.Quantity{Amount: 200, Unit: domain.Grams},
+	}
+	record, err := service.AddRecord(context.Background(), "brew-123", "session-123", recipe)
+
+	if err != nil {
+		t.Fatalf("AddRecord() error = %v, want nil", err)
+	}
+	if record.ID == "" {
+		t.Fatal("AddRecord() record.ID is empty")
+	}
+	if record.BrewID != "brew-123" || record.SessionID != "session-123" {
+		t.Fatalf("AddRecord() record = %+v", record)
+	}
+	if record.CreatedAt.IsZero() {
+		t.Fatal("AddRecord() record.CreatedAt is zero")
+	}
+	if receivedRecord == nil || receivedRecord.Recipe.Water.Amount != 3 {
+		t.Fatalf("Save called with record = %+v", receivedRecord)
+	}
+}
+
+func TestBrewService_AddRecord_OtherSessionNotFound(t *testing.T) {
+	saveCalled := false
+
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-owner"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		SaveFunc: func(ctx context.Context, record *domain.BrewRecord) error {
+			saveCalled = true
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	_, err := service.AddRecord(context.Background(), "brew-123", "session-other", domain.Recipe{Water: domain.Quantity{Amount: 3, Unit: domain.Liters}})
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("AddRecord() error = %v, want ErrNotFound", err)
+	}
+	if saveCalled {
+		t.Fatal("Save called for foreign brew")
+	}
+}
+
+func TestBrewService_AddRecord_SubmitsRecord(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	record, err := service.AddRecord(context.Background(), "brew-123", "session-123", domain.Recipe{Water: domain.Quantity{Amount: 3, Unit: domain.Liters}})
+
+	if err != nil {
+		t.Fatalf("AddRecord() error = %v, want nil", err)
+	}
+	if !record.IsSubmitted() {
+		t.Fatal("AddRecord() record is not submitted")
+	}
+}
+
+func TestBrewService_AddRecord_RejectsInvalidRecipe(t *testing.T) {
+	saveCalled := false
+
+	recordRepo := &mocks.BrewRecordRepository{
+		SaveFunc: func(ctx context.Context, record *domain.BrewRecord) error {
+			saveCalled = true
+			return nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	recipe := domain.Recipe{
+		Water: domain.Quantity{Amount: 3, Unit: domain.Grams},
+		Tea:   domain.Quantity{Amount: -1, Unit: domain.Grams},
+	}
+	_, err := service.AddRecord(context.Background(), "brew-123", "session-123", recipe)
+
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Fields) != 2 {
+		t.Fatalf("AddRecord() error = %v, want two field errors", err)
+	}
+	if saveCalled {
+		t.Fatal("Save called for invalid recipe")
+	}
+}
+
+func TestBrewService_CreateBrew_NormalizesName(t *testing.T) {
+	var saved *domain.Brew
+
+	brewRepo := &mocks.BrewRepository{
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			saved = brew
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{
+			GenerateFunc: func(ctx context.Context, name string) (string, error) {
+				return "brew-123", nil
+			},
+		},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	brew, err := service.CreateBrew(context.Background(), "  Скубі \t на  кухні ", "session-123")
+
+	if err != nil {
+		t.Fatalf("CreateBrew() error = %v, want nil", err)
+	}
+	if brew.Name != "Скубі на кухні" || saved == nil || saved.Name != brew.Name {
+		t.Fatalf("CreateBrew() name = %q, saved %+v", brew.Name, saved)
+	}
+
+	if _, err := service.CreateBrew(context.Background(), strings.Repeat("я", domain.MaxBrewNameLength+1), "session-123"); !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("CreateBrew() with a long name error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestBrewService_AppendNote_Success(t *testing.T) {
+	var receivedNote *domain.RecordNote
+
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			return &domain.BrewRecord{ID: id, BrewID: "brew-123", SessionID: "session-123"}, nil
+		},
+		AppendNoteFunc: func(ctx context.Context, note *domain.RecordNote) error {
+			receivedNote = note
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	note, err := service.AppendNote(context.Background(), "record-123", "session-123", "tastes great")
+
+	if err != nil {
+		t.Fatalf("AppendNote() error = %v, want nil", err)
+	}
+	if note.ID == "" || note.RecordID != "record-123" || note.SessionID != "session-123" {
+		t.Fatalf("AppendNote() note = %+v", note)
+	}
+	if note.CreatedAt.IsZero() {
+		t.Fatal("AppendNote() note.CreatedAt is zero")
+	}
+	if receivedNote == nil || receivedNote.Text != "tastes great" {
+		t.Fatalf("AppendNote called with note = %+v", receivedNote)
+	}
+}
+
+func TestBrewService_AppendNote_IndexesNoteUnderJarOwner(t *testing.T) {
+	var indexed *domain.SearchDocument
+
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			return &domain.BrewRecord{ID: id, BrewID: "brew-123", SessionID: "session-before-transfer"}, nil
+		},
+	}
+	searchRepo := &mocks.SearchRepository{
+		IndexFunc: func(ctx context.Context, document *domain.SearchDocument) error {
+			indexed = document
+			return errors.New("index unavailable")
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(searchRepo),
+		newTestClock(),
+	)
+
+	note, err := service.AppendNote(context.Background(), "record-123", "session-123", "  more hibiscus next time ")
+
+	if err != nil {
+		t.Fatalf("AppendNote() error = %v, want nil despite the failing index", err)
+	}
+	want := domain.SearchDocument{
+		Kind:      domain.NoteSearchKind,
+		ID:        note.ID,
+		SessionID: "session-123",
+		BrewID:    "brew-123",
+		RecordID:  "record-123",
+		Text:      "more hibiscus next time",
+		At:        testNow,
+	}
+	if indexed == nil || *indexed != want {
+		t.Fatalf("Index called with %+v, want %+v", indexed, want)
+	}
+}
+
+func TestBrewService_Search_RequiresAWord(t *testing.T) {
+	searched := false
+	searchRepo := &mocks.SearchRepository{
+		SearchFunc: func(ctx context.Context, sessionID string, query string, limit int) ([]*domain.SearchHit, error) {
+			searched = true
+			return nil, nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(searchRepo),
+		newTestClock(),
+	)
+
+	for _, search := range []string{"", " - ", strings.Repeat("a", domain.MaxSearchLength+1)} {
+		_, err := service.Search(context.Background(), "session-123", search, 10)
+
+		var invalid *domain.ValidationError
+		if !errors.As(err, &invalid) || invalid.Fields[0].Field != "search" {
+			t.Errorf("Search(%q) error = %v, want a search field error", search, err)
+		}
+	}
+	if searched {
+		t.Fatal("Search() queried the index without a usable search")
+	}
+}
+
+func TestBrewService_AppendNote_OtherSessionNotFound(t *testing.T) {
+	appendCalled := false
+
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-owner"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			return &domain.BrewRecord{ID: id, BrewID: "brew-123", SessionID: "session-owner"}, nil
+		},
+		AppendNoteFunc: func(ctx context.Context, note *domain.RecordNote) error {
+			appendCalled = true
+			return nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	_, err := service.AppendNote(context.Background(), "record-123", "session-other", "sneaky")
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("AppendNote() error = %v, want ErrNotFound", err)
+	}
+	if appendCalled {
+		t.Fatal("AppendNote called for foreign record")
+	}
+}
+
+func TestBrewService_GetRecord_ReturnsNotes(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-123"}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			return &domain.BrewRecord{ID: id, BrewID: "brew-123"}, nil
+		},
+		GetNotesFunc: func(ctx context.Context, recordID string) ([]*domain.RecordNote, error) {
+			return []*domain.RecordNote{{ID: "note-1", RecordID: recordID}, {ID: "note-2", RecordID: recordID}}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	annotated, err := service.GetRecord(context.Background(), "record-123", "session-123")
+
+	if err != nil {
+		t.Fatalf("GetRecord() error = %v, want nil", err)
+	}
+	if annotated.Record.ID != "record-123" {
+		t.Fatalf("GetRecord() record.ID = %v, want record-123", annotated.Record.ID)
+	}
+	if len(annotated.Notes) != 2 || annotated.Notes[1].ID != "note-2" {
+		t.Fatalf("GetRecord() notes = %+v", annotated.Notes)
+	}
+}
+
+func newLookupTestService(brews map[string]*domain.Brew, records []*domain.BrewRecord) *BrewService {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			brew, ok := brews[id]
+			if !ok {
+				return nil, fmt.Errorf("brew %s: %w", id, domain.ErrNotFound)
+			}
+			return brew, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByBrewIDFunc: func(
+			ctx context.Context,
+			brewID string,
+			pointer *string,
+			limit int,
+		) (*ports.PaginatedResult[*domain.BrewRecord], error) {
+			return &ports.PaginatedResult[*domain.BrewRecord]{Items: records, TotalCount: len(records)}, nil
+		},
+		GetNotesFunc: func(ctx context.Context, recordID string) ([]*domain.RecordNote, error) {
+			return []*domain.RecordNote{{ID: "note-1", RecordID: recordID, Text: "fizzy"}}, nil
+		},
+	}
+	identifierGen := &mocks.IdentifierGenerator{
+		NormalizeFunc: func(ctx context.Context, identifier string) (string, error) {
+			if identifier != "7kq2m9xd4" {
+				return "", fmt.Errorf("identifier %s: %w", identifier, domain.ErrInvalid)
+			}
+			return "7KQ-2M9-XD4", nil
+		},
+	}
+	qrGenerator := &mocks.QRCodeGenerator{
+		ParseQRCodeFunc: func(ctx context.Context, qrData []byte) (string, error) {
+			return string(qrData), nil
+		},
+	}
+	return NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		identifierGen,
+		NewQRService(qrGenerator),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+}
+
+func TestBrewService_LookupBrew_TypedCode(t *testing.T) {
+	brews := map[string]*domain.Brew{
+		"7KQ-2M9-XD4": {ID: "7KQ-2M9-XD4", SessionID: "session-1"},
+	}
+	records := []*domain.BrewRecord{
+		{ID: "record-1", BrewID: "7KQ-2M9-XD4"},
+		{ID: "record-2", BrewID: "7KQ-2M9-XD4"},
+	}
+	service := newLookupTestService(brews, records)
+
+	details, err := service.LookupBrew(context.Background(), []byte("7kq2m9xd4"), "session-1")
+	if err != nil {
+		t.Fatalf("LookupBrew() error = %v, want nil", err)
+	}
+	if details.Brew.ID != "7KQ-2M9-XD4" || len(details.History) != 2 {
+		t.Fatalf("LookupBrew() = %+v", details)
+	}
+	if details.LatestRecord == nil || details.LatestRecord.Record.ID != "record-2" || len(details.LatestRecord.Notes) != 1 {
+		t.Fatalf("LookupBrew() latest record = %+v", details.LatestRecord)
+	}
+}
+
+func TestBrewService_LookupBrew_LegacyIdentifier(t *testing.T) {
+	brews := map[string]*domain.Brew{
+		"mfrgg43b": {ID: "mfrgg43b", SessionID: "session-1"},
+	}
+	service := newLookupTestService(brews, nil)
+
+	details, err := service.LookupBrew(context.Background(), []byte("mfrgg43b"), "session-1")
+	if err != nil {
+		t.Fatalf("LookupBrew() error = %v, want nil", err)
+	}
+	if details.Brew.ID != "mfrgg43b" || details.LatestRecord != nil || len(details.History) != 0 {
+		t.Fatalf("LookupBrew() = %+v", details)
+	}
+}
+
+func TestBrewService_LookupBrew_Mistyped(t *testing.T) {
+	service := newLookupTestService(map[string]*domain.Brew{}, nil)
+
+	_, err := service.LookupBrew(context.Background(), []byte("7kq2m9xd5"), "session-1")
+	if !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("LookupBrew() error = %v, want ErrInvalid", err)
+	}
+}
+
+type inTransactionKey struct{}
+
+func TestBrewService_CreateBrew_FailsWithoutAuditEntry(t *testing.T) {
+	var entries []*domain.AuditEntry
+	auditRepo := &mocks.AuditRepository{
+		AppendFunc: func(ctx context.Context, entry *domain.AuditEntry) error {
+			if ctx.Value(inTransactionKey{}) == nil {
+				t.Error("Append() called outside the transaction saving the jar")
+			}
+			entries = append(entries, entry)
+			return errors.New("disk full")
+		},
+	}
+	var rolledBack bool
+	transactor := &mocks.Transactor{
+		WithinTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(context.WithValue(ctx, inTransactionKey{}, true))
+			rolledBack = err != nil
+			return err
+		},
+	}
+	identifierGen := &mocks.IdentifierGenerator{
+		GenerateFunc: func(ctx context.Context, name string) (string, error) {
+			return "brew-1", nil
+		},
+	}
+	service := NewBrewService(
+		&mocks.BrewRepository{},
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		identifierGen,
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(auditRepo, transactor, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	ctx := WithShareGrant(context.Background(), &domain.ShareGrant{
+		SessionID: "session-1",
+		Token:     "token-1",
+		Scope:     domain.ReadWriteScope,
+	})
+	if _, err := service.CreateBrew(ctx, "jar", "session-1"); err == nil {
+		t.Fatal("CreateBrew() error = nil, want the audit failure reported")
+	}
+	if !rolledBack {
+		t.Error("jar saved without its audit entry, want the transaction rolled back")
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Operation != domain.AuditBrewCreated || entry.SessionID != "session-1" ||
+		entry.BrewID != "brew-1" || entry.ShareToken != "token-1" || !entry.IsShared() {
+		t.Errorf("audit entry = %+v", entry)
+	}
+}
+
+func TestBrewService_ShareGrantScope(t *testing.T) {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-1"}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+
+	readOnly := WithShareGrant(context.Background(), &domain.ShareGrant{SessionID: "session-1", Scope: domain.ReadOnlyScope})
+	if _, err := service.GetBrew(readOnly, "brew-1", "session-1"); err != nil {
+		t.Errorf("GetBrew() error = %v, want read access", err)
+	}
+	if _, err := service.CreateBrew(readOnly, "Jar", "session-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateBrew() error = %v, want ErrForbidden", err)
+	}
+	if _, err := service.AddRecord(readOnly, "brew-1", "session-1", domain.Recipe{}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("AddRecord() error = %v, want ErrForbidden", err)
+	}
+
+	foreign := WithShareGrant(context.Background(), &domain.ShareGrant{SessionID: "session-2", Scope: domain.ReadWriteScope})
+	if _, err := service.GetBrew(foreign, "brew-1", "session-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetBrew() error = %v, want ErrForbidden", err)
+	}
+
+	readWrite := WithShareGrant(context.Background(), &domain.ShareGrant{SessionID: "session-1", Scope: domain.ReadWriteScope})
+	_, err := service.OfferTransfer(readWrite, "brew-1", "session-1")
+	if !errors.Is(err, domain.ErrForbidden) || !strings.Contains(err.Error(), "offer a jar for transfer") {
+		t.Errorf("OfferTransfer() error = %v, want ErrForbidden naming the action", err)
+	}
+}
+
+func TestBrewService_ListAuditEntries_RedactsPreviousOwner(t *testing.T) {
+	entries := []*domain.AuditEntry{
+		{ID: "entry-1", SessionID: "session-old", ShareToken: "share-old", Operation: domain.AuditBrewCreated, BrewID: "brew-123"},
+	}
+	auditRepo := &mocks.AuditRepository{
+		AppendFunc: func(ctx context.Context, entry *domain.AuditEntry) error {
+			entries = append(entries, entry)
+			return nil
+		},
+		GetByBrewIDFunc: func(ctx context.Context, brewID string, pointer *string, limit int) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+			items := make([]*domain.AuditEntry, 0, len(entries))
+			for _, entry := range entries {
+				clone := *entry
+				items = append(items, &clone)
+			}
+			return &ports.PaginatedResult[*domain.AuditEntry]{Items: items, TotalCount: len(items)}, nil
+		},
+	}
+	transfer := &domain.TransferToken{Token: "token-1", BrewID: "brew-123", FromSessionID: "session-old"}
+	transferRepo := &mocks.TransferRepository{
+		GetByTokenFunc: func(ctx context.Context, token string) (*domain.TransferToken, error) {
+			return transfer, nil
+		},
+		RedeemFunc: func(ctx context.Context, token string, toSessionID string, now time.Time) (*domain.TransferToken, error) {
+			return transfer, nil
+		},
+	}
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-new"}, nil
+		},
+	}
+	service := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		transferRepo,
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(auditRepo, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	ctx := context.Background()
+
+	if _, err := service.AcceptTransfer(ctx, "token-1", "session-new"); err != nil {
+		t.Fatalf("AcceptTransfer() error = %v", err)
+	}
+	result, err := service.ListAuditEntries(ctx, "brew-123", "session-new", nil, 0)
+	if err != nil {
+		t.Fatalf("ListAuditEntries() error = %v", err)
+	}
+
+	own := 0
+	for _, entry := range result.Items {
+		if entry.SessionID == "session-old" || entry.ShareToken != "" {
+			t.Errorf("ListAuditEntries() entry %+v reveals the previous owner", entry)
+		}
+		if entry.SessionID == "session-new" {
+			own++
+		}
+	}
+	if len(result.Items) != 3 || own != 1 {
+		t.Errorf("ListAuditEntries() = %d entries with %d of the new owner, want 3 with 1", len(result.Items), own)
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+type LabelService struct {
+	brewService *BrewService
+	qrService   *QRService
+	renderer    ports.LabelSheetRenderer
+}
+
+func NewLabelService(
+	brewService *BrewService,
+	qrService *QRService,
+	renderer ports.LabelSheetRenderer,
+) *LabelService {
+	return &LabelService{
+		brewService: brewService,
+		qrService:   qrService,
+		renderer:    renderer,
+	}
+}
+
+// GenerateLabelSheet lays out labels for the given jars, or for every jar
+// of the session when brewIDs is empty, and returns the rendered pages.
+func (s *LabelService) GenerateLabelSheet(
+	ctx context.Context,
+	sessionID string,
+	brewIDs []string,
+	presetName string,
+	skip int,
+) ([][]byte, error) {
+	logger.Debug("Generating label sheet", "session_id", sessionID, "brews", len(brewIDs), "preset", presetName)
+
+	if presetName == "" {
+		presetName = domain.DefaultLabelPreset
+	}
+	preset, err := domain.LabelPresetByName(presetName)
+	if err != nil {
+		return nil, err
+	}
+	if skip < 0 || skip >= preset.Capacity() {
+		return nil, domain.InvalidField("skip", fmt.Sprintf("must be between 0 and %d", preset.Capacity()-1))
+	}
+
+	brews, err := s.collectBrews(ctx, sessionID, brewIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(brews) == 0 {
+		return nil, fmt.Errorf("no jars to print: %w", domain.ErrInvalid)
+	}
+
+	sheet := domain.LabelSheet{
+		Preset: preset,
+		Labels: make([]domain.Label, 0, len(brews)),
+		Skip:   skip,
+	}
+	for _, brew := range brews {
+		qrCode, err := s.qrService.GenerateQRCode(ctx, brew.ID, ports.QRFormatPNG)
+		if err != nil {
+			return nil, err
+		}
+		sheet.Labels = append(sheet.Labels, domain.Label{
+			BrewID: brew.ID,
+			Name:   brew.Name,
+			QRCode: qrCode,
+		})
+	}
+
+	pages, err := s.renderer.RenderLabelSheet(ctx, sheet)
+	if err != nil {
+		logger.Error("Failed to render label sheet", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	logger.Debug("Label sheet generated successfully", "session_id", sessionID, "labels", len(sheet.Labels), "pages", len(pages))
+	return pages, nil
+}
+
+func (s *LabelService) collectBrews(
+	ctx context.Context,
+	sessionID string,
+	brewIDs []string,
+) ([]*domain.Brew, error) {
+	if len(brewIDs) == 0 {
+		result, err := s.brewService.ListBrews(ctx, sessionID, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		return result.Items, nil
+	}
+
+	brews := make([]*domain.Brew, 0, len(brewIDs))
+	for _, id := range brewIDs {
+		brew, err := s.brewService.GetBrew(ctx, id, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		brews = append(brews, brew)
+	}
+	return brews, nil
+}
@@ -20,13 +20,15 @@ func NewQRService(qrGenerator ports.QRCodeGenerator) *QRService {
 func (s *QRService) GenerateQRCode(
 	ctx context.Context,
 	brewID string,
+	format ports.QRFormat,
 ) ([]byte, error) {
-	logger.Debug("Generating QR code", "brew_id", brewID)
-	qrData, err := s.qrGenerator.GenerateQRCode(ctx, brewID)
+	logger.Debug("Generating QR code", "brew_id", brewID, "format", format)
+	qrData, err := s.qrGenerator.GenerateQRCode(ctx, brewID, format)
 	if err != nil {
+		logger.Error("Failed to generate QR code", "error", err, "brew_id", brewID)
 		return nil, err
 	}
-	logger.Debug("QR code generated successfully", "brew_id", brewID, "data_size", len(qrData))
+	logger.Debug("QR code generated successfully", "brew_id", brewID, "format", format, "data_size", len(qrData))
 	return qrData, nil
 }
 
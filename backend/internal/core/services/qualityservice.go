@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+type QualityService struct {
+	qualityRepo ports.QualityRepository
+	recordRepo  ports.BrewRecordRepository
+	brewService *BrewService
+}
+
+func NewQualityService(
+	qualityRepo ports.QualityRepository,
+	recordRepo ports.BrewRecordRepository,
+	brewService *BrewService,
+) *QualityService {
+	return &QualityService{
+		qualityRepo: qualityRepo,
+		recordRepo:  recordRepo,
+		brewService: brewService,
+	}
+}
+
+func (s *QualityService) AddEvaluation(
+	ctx context.Context,
+	recordID string,
+	sessionID string,
+	score domain.QualityScore,
+	notes string,
+	suggestions string,
+) (*domain.QualityEvaluation, error) {
+	logger.Debug("Adding quality evaluation", "record_id", recordID, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if err := score.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.brewService.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	record, err := s.recordRepo.GetByID(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.brewService.GetBrew(ctx, record.BrewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	evaluation := domain.NewQualityEvaluation(
+		uuid.NewString(),
+		record,
+		sessionID,
+		score,
+		notes,
+		suggestions,
+		s.brewService.clock.Now(),
+	)
+
+	err = s.brewService.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.qualityRepo.Save(ctx, evaluation); err != nil {
+			return err
+		}
+		return s.brewService.auditService.record(ctx, domain.AuditEvaluationAdded, sessionID, record.BrewID, recordID)
+	})
+	if err != nil {
+		logger.Error("Failed to save quality evaluation", "error", err, "record_id", recordID)
+		return nil, err
+	}
+
+	logger.Debug("Quality evaluation added successfully", "id", evaluation.ID, "record_id", recordID)
+	return evaluation, nil
+}
+
+func (s *QualityService) ListEvaluations(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+) ([]*domain.QualityEvaluation, error) {
+	logger.Debug("Listing quality evaluations", "brew_id", brewID, "session_id", sessionID)
+
+	if _, err := s.brewService.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	evaluations, err := s.qualityRepo.GetByBrewID(ctx, brewID)
+	if err != nil {
+		logger.Error("Failed to list quality evaluations", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+func (s *QualityService) JarMetrics(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+) (*domain.QualityMetrics, error) {
+	logger.Debug("Aggregating jar quality", "brew_id", brewID, "session_id", sessionID)
+
+	evaluations, err := s.ListEvaluations(ctx, brewID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := domain.AggregateQuality(evaluations)
+	return &metrics, nil
+}
+
+// RecipeMetrics aggregates every evaluation across the session's jars by
+// recipe, best rated first, so brewers can see which recipes work.
+func (s *QualityService) RecipeMetrics(
+	ctx context.Context,
+	sessionID string,
+) ([]*domain.RecipeQuality, error) {
+	logger.Debug("Aggregating recipe quality", "session_id", sessionID)
+
+	brews, err := s.brewService.ListBrews(ctx, sessionID, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		quality     *domain.RecipeQuality
+		evaluations []*domain.QualityEvaluation
+		brews       map[string]bool
+		latest      *domain.QualityEvaluation
+	}
+	groups := make(map[string]*group)
+	recipes := make(map[string]domain.Recipe)
+
+	for _, brew := range brews.Items {
+		evaluations, err := s.qualityRepo.GetByBrewID(ctx, brew.ID)
+		if err != nil {
+			logger.Error("Failed to list quality evaluations", "error", err, "brew_id", brew.ID)
+			return nil, err
+		}
+
+		for _, evaluation := range evaluations {
+			recipe, ok := recipes[evaluation.RecordID]
+			if !ok {
+				record, err := s.recordRepo.GetByID(ctx, evaluation.RecordID)
+				if err != nil {
+					logger.Error("Failed to get evaluated record", "error", err, "record_id", evaluation.RecordID)
+					return nil, err
+				}
+				recipe = record.Recipe
+				recipes[evaluation.RecordID] = recipe
+			}
+
+			key := recipe.Key()
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					quality: &domain.RecipeQuality{Key: key},
+					brews:   make(map[string]bool),
+				}
+				groups[key] = g
+			}
+			g.evaluations = append(g.evaluations, evaluation)
+			if !g.brews[brew.ID] {
+				g.brews[brew.ID] = true
+				g.quality.BrewIDs = append(g.quality.BrewIDs, brew.ID)
+			}
+			if g.latest == nil || evaluation.CreatedAt.After(g.latest.CreatedAt) {
+				g.latest = evaluation
+				g.quality.Recipe = recipe
+			}
+		}
+	}
+
+	result := make([]*domain.RecipeQuality, 0, len(groups))
+	for _, g := range groups {
+		g.quality.Metrics = domain.AggregateQuality(g.evaluations)
+		result = append(result, g.quality)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Metrics.AverageRating != result[j].Metrics.AverageRating {
+			return result[i].Metrics.AverageRating > result[j].Metrics.AverageRating
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	logger.Debug("Recipe quality aggregated successfully", "session_id", sessionID, "recipes", len(result))
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/core/ports/mocks"
+)
+
+func newQualityTestService(
+	qualityRepo *mocks.QualityRepository,
+	records map[string]*domain.BrewRecord,
+) *QualityService {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-1"}, nil
+		},
+		GetBySessionIDFunc: func(
+			ctx context.Context,
+			sessionID string,
+			pointer *string,
+			limit int,
+		) (*ports.PaginatedResult[*domain.Brew], error) {
+			return &ports.PaginatedResult[*domain.Brew]{
+				Items: []*domain.Brew{
+					{ID: "brew-1", SessionID: sessionID},
+					{ID: "brew-2", SessionID: sessionID},
+				},
+				TotalCount: 2,
+			}, nil
+		},
+	}
+	recordRepo := &mocks.BrewRecordRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.BrewRecord, error) {
+			record, ok := records[id]
+			if !ok {
+				return nil, domain.ErrNotFound
+			}
+			return record, nil
+		},
+	}
+	brewService := NewBrewService(
+		brewRepo,
+		recordRepo,
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	return NewQualityService(qualityRepo, recordRepo, brewService)
+}
+
+func TestQualityService_AddEvaluation_Success(t *testing.T) {
+	var saved *domain.QualityEvaluation
+	records := map[string]*domain.BrewRecord{
+		"record-1": {ID: "record-1", BrewID: "brew-1"},
+	}
+	service := newQualityTestService(&mocks.QualityRepository{
+		SaveFunc: func(ctx context.Context, evaluation *domain.QualityEvaluation) error {
+			saved = evaluation
+			return nil
+		},
+	}, records)
+
+	score := domain.QualityScore{Rating: 5, Sweetness: 2, Fizz: 4}
+	evaluation, err := service.AddEvaluation(context.Background(), "record-1", "session-1", score, "great", "")
+	if err != nil {
+		t.Fatalf("AddEvaluation() error = %v, want nil", err)
+	}
+	if saved != evaluation {
+		t.Error("AddEvaluation() did not save the returned evaluation")
+	}
+	if evaluation.BrewID != "brew-1" || evaluation.RecordID != "record-1" || evaluation.Score != score {
+		t.Errorf("AddEvaluation() = %+v", evaluation)
+	}
+}
+
+func TestQualityService_AddEvaluation_InvalidScore(t *testing.T) {
+	service := newQualityTestService(&mocks.QualityRepository{}, nil)
+
+	_, err := service.AddEvaluation(context.Background(), "record-1", "session-1", domain.QualityScore{Rating: 9}, "", "")
+	if !errors.Is(err, domain.ErrInvalid) {
+		t.Fatalf("AddEvaluation() error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestQualityService_RecipeMetrics_GroupsByRecipe(t *testing.T) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	oneLiter := domain.Recipe{
+		Water:   domain.Quantity{Amount: 1, Unit: domain.Liters},
+		Sugar:   domain.Quantity{Amount: 80, Unit: domain.Grams},
+		TeaType: domain.BlackTea,
+	}
+	twoLiters := domain.Recipe{
+		Water:   domain.Quantity{Amount: 2, Unit: domain.Liters},
+		Sugar:   domain.Quantity{Amount: 160, Unit: domain.Grams},
+		TeaType: domain.BlackTea,
+	}
+	green := domain.Recipe{
+		Water:   domain.Quantity{Amount: 1, Unit: domain.Liters},
+		Sugar:   domain.Quantity{Amount: 80, Unit: domain.Grams},
+		TeaType: domain.GreenTea,
+	}
+	records := map[string]*domain.BrewRecord{
+		"record-1": {ID: "record-1", BrewID: "brew-1", Recipe: oneLiter},
+		"record-2": {ID: "record-2", BrewID: "brew-2", Recipe: twoLiters},
+		"record-3": {ID: "record-3", BrewID: "brew-2", Recipe: green},
+	}
+	evaluations := map[string][]*domain.QualityEvaluation{
+		"brew-1": {
+			{ID: "e1", RecordID: "record-1", BrewID: "brew-1", Score: domain.QualityScore{Rating: 3}, CreatedAt: base},
+		},
+		"brew-2": {
+			{ID: "e2", RecordID: "record-2", BrewID: "brew-2", Score: domain.QualityScore{Rating: 5}, CreatedAt: base.Add(time.Hour)},
+			{ID: "e3", RecordID: "record-3", BrewID: "brew-2", Score: domain.QualityScore{Rating: 2}, CreatedAt: base.Add(2 * time.Hour)},
+		},
+	}
+	service := newQualityTestService(&mocks.QualityRepository{
+		GetByBrewIDFunc: func(ctx context.Context, brewID string) ([]*domain.QualityEvaluation, error) {
+			return evaluations[brewID], nil
+		},
+	}, records)
+
+	recipes, err := service.RecipeMetrics(context.Background(), "session-1")
+	if err != nil {
+		t.Fatalf("RecipeMetrics() error = %v, want nil", err)
+	}
+	if len(recipes) != 2 {
+		t.Fatalf("RecipeMetrics() = %d recipes, want 2", len(recipes))
+	}
+
+	best := recipes[0]
+	if best.Metrics.Count != 2 || best.Metrics.AverageRating != 4 || len(best.BrewIDs) != 2 {
+		t.Errorf("RecipeMetrics()[0] = %+v", best)
+	}
+	if best.Recipe.Water.Amount != 2 {
+		t.Errorf("RecipeMetrics()[0].Recipe = %+v, want the latest evaluated recipe", best.Recipe)
+	}
+	if recipes[1].Recipe.TeaType != domain.GreenTea {
+		t.Errorf("RecipeMetrics()[1].Recipe = %+v, want green tea", recipes[1].Recipe)
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+// SearchService keeps the full-text index in step with the jars, records
+// and notes BrewService writes, and answers searches over it.
+type SearchService struct {
+	searchRepo ports.SearchRepository
+}
+
+func NewSearchService(searchRepo ports.SearchRepository) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// index is best effort: a search missing the newest document is better than
+// refusing the edit, so a failing index write is logged rather than
+// reported to the caller.
+func (s *SearchService) index(ctx context.Context, document *domain.SearchDocument) {
+	if err := s.searchRepo.Index(ctx, document); err != nil {
+		logger.Error("Failed to index search document", "error", err, "kind", document.Kind, "id", document.ID)
+	}
+}
+
+// moveBrew follows a transfer; it is best effort for the same reason.
+func (s *SearchService) moveBrew(ctx context.Context, brewID string, sessionID string) {
+	if err := s.searchRepo.MoveBrew(ctx, brewID, sessionID); err != nil {
+		logger.Error("Failed to move search documents", "error", err, "brew_id", brewID, "session_id", sessionID)
+	}
+}
+
+func (s *SearchService) search(
+	ctx context.Context,
+	sessionID string,
+	query string,
+	limit int,
+) ([]*domain.SearchHit, error) {
+	hits, err := s.searchRepo.Search(ctx, sessionID, query, limit)
+	if err != nil {
+		logger.Error("Failed to search", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+	return hits, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+type ReapMode string
+
+const (
+	// ReapDeactivate keeps expired sessions, with their jars, for the
+	// record but stops them and their share tokens from being used.
+	ReapDeactivate ReapMode = "deactivate"
+	// ReapPurge deletes the share tokens of expired sessions and leaves the
+	// sessions as tombstones, so neither their jars nor their IDs can be
+	// used again.
+	ReapPurge ReapMode = "purge"
+)
+
+// ReapReport tells what one pass of the reaper did, or in a dry run, what
+// it would have done.
+type ReapReport struct {
+	Mode        ReapMode
+	DryRun      bool
+	SessionIDs  []string
+	ShareTokens int
+	Failed      int
+}
+
+// SessionReaper expires sessions nobody has used for longer than the
+// configured TTL.
+type SessionReaper struct {
+	sessionRepo  ports.SessionRepository
+	auditService *AuditService
+	clock        ports.Clock
+	ttl          time.Duration
+	mode         ReapMode
+	dryRun       bool
+}
+
+func NewSessionReaper(
+	sessionRepo ports.SessionRepository,
+	auditService *AuditService,
+	clock ports.Clock,
+	ttl time.Duration,
+	mode ReapMode,
+	dryRun bool,
+) *SessionReaper {
+	return &SessionReaper{
+		sessionRepo:  sessionRepo,
+		auditService: auditService,
+		clock:        clock,
+		ttl:          ttl,
+		mode:         mode,
+		dryRun:       dryRun,
+	}
+}
+
+// Run reaps once per interval until ctx is cancelled.
+func (r *SessionReaper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Reap(ctx, r.clock.Now()); err != nil {
+				logger.Error("Failed to reap sessions", "error", err)
+			}
+		}
+	}
+}
+
+// Reap expires the sessions that are expired at now. A session that fails
+// to update is counted and skipped so one bad row cannot stall the rest.
+func (r *SessionReaper) Reap(ctx context.Context, now time.Time) (*ReapReport, error) {
+	logger.Debug("Reaping sessions", "mode", r.mode, "dry_run", r.dryRun, "ttl", r.ttl.String())
+
+	sessions, err := r.sessionRepo.GetExpired(ctx, now, r.ttl)
+	if err != nil {
+		logger.Error("Failed to list expired sessions", "error", err)
+		return nil, err
+	}
+
+	report := &ReapReport{
+		Mode:       r.mode,
+		DryRun:     r.dryRun,
+		SessionIDs: []string{},
+	}
+	for _, session := range sessions {
+		tokens := activeShareTokens(session, now)
+		if r.isReaped(session, tokens) {
+			continue
+		}
+
+		if !r.dryRun {
+			if err := r.expire(ctx, session); err != nil {
+				logger.Error("Failed to expire session", "error", err, "session_id", session.ID, "mode", r.mode)
+				report.Failed++
+				continue
+			}
+		}
+		report.SessionIDs = append(report.SessionIDs, session.ID)
+		report.ShareTokens += tokens
+	}
+
+	logger.Info(
+		"Sessions reaped",
+		"mode", r.mode,
+		"dry_run", r.dryRun,
+		"sessions", len(report.SessionIDs),
+		"share_tokens", report.ShareTokens,
+		"failed", report.Failed,
+	)
+	return report, nil
+}
+
+func (r *SessionReaper) expire(ctx context.Context, session *domain.Session) error {
+	return r.auditService.transact(ctx, func(ctx context.Context) error {
+		if r.mode == ReapPurge {
+			session.Purge()
+			if err := r.sessionRepo.Update(ctx, session); err != nil {
+				return err
+			}
+			return r.auditService.record(ctx, domain.AuditSessionDeleted, session.ID, "", "")
+		}
+
+		session.Deactivate()
+		if err := r.sessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+		return r.auditService.record(ctx, domain.AuditSessionExpired, session.ID, "", "")
+	})
+}
+
+// isReaped tells whether an earlier pass already left the session as the
+// mode leaves it, so it is not written again on every pass.
+func (r *SessionReaper) isReaped(session *domain.Session, tokens int) bool {
+	if session.IsActive {
+		return false
+	}
+	if r.mode == ReapPurge {
+		return len(session.ShareTokens) == 0
+	}
+	return tokens == 0
+}
+
+func activeShareTokens(session *domain.Session, now time.Time) int {
+	count := 0
+	for _, token := range session.ShareTokens {
+		if token.IsUsable(now) {
+			count++
+		}
+	}
+	return count
+}
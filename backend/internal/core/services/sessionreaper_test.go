@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports/mocks"
+)
+
+func newExpiredSessions(now time.Time) []*domain.Session {
+	return []*domain.Session{
+		{
+			ID:           "idle",
+			LastAccessed: now.Add(-48 * time.Hour),
+			IsActive:     true,
+			ShareTokens: []domain.ShareToken{
+				{Token: "token-1", Scope: domain.ReadOnlyScope, IsActive: true},
+				{Token: "token-2", Scope: domain.ReadOnlyScope, IsActive: false},
+			},
+		},
+		{ID: "already-inactive", LastAccessed: now.Add(-48 * time.Hour)},
+	}
+}
+
+func TestSessionReaper_Deactivate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	var updated []*domain.Session
+	sessionRepo := &mocks.SessionRepository{
+		GetExpiredFunc: func(ctx context.Context, at time.Time, ttl time.Duration) ([]*domain.Session, error) {
+			if !at.Equal(now) || ttl != 24*time.Hour {
+				t.Errorf("GetExpired(%v, %v), want (%v, 24h)", at, ttl, now)
+			}
+			return newExpiredSessions(now), nil
+		},
+		UpdateFunc: func(ctx context.Context, session *domain.Session) error {
+			updated = append(updated, session)
+			return nil
+		},
+	}
+	reaper := NewSessionReaper(sessionRepo, NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock(), 24*time.Hour, ReapDeactivate, false)
+
+	report, err := reaper.Reap(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Reap() error = %v", err)
+	}
+	if len(report.SessionIDs) != 1 || report.SessionIDs[0] != "idle" || report.ShareTokens != 1 {
+		t.Fatalf("Reap() report = %+v", report)
+	}
+	if len(updated) != 1 || updated[0].IsActive || updated[0].ShareTokens[0].IsActive {
+		t.Fatalf("Reap() updated = %+v, want the idle session and its tokens deactivated", updated)
+	}
+}
+
+func TestSessionReaper_Purge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	sessions := append(newExpiredSessions(now), &domain.Session{
+		ID:           "deactivated",
+		LastAccessed: now.Add(-48 * time.Hour),
+		ShareTokens:  []domain.ShareToken{{Token: "token-3", Scope: domain.ReadOnlyScope, IsActive: false}},
+	})
+	var purged []*domain.Session
+	sessionRepo := &mocks.SessionRepository{
+		GetExpiredFunc: func(ctx context.Context, at time.Time, ttl time.Duration) ([]*domain.Session, error) {
+			return sessions, nil
+		},
+		UpdateFunc: func(ctx context.Context, session *domain.Session) error {
+			if session.ID == "deactivated" {
+				return errors.New("database is locked")
+			}
+			purged = append(purged, session)
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			t.Errorf("Delete(%s), want the session kept as a tombstone", id)
+			return nil
+		},
+	}
+	reaper := NewSessionReaper(sessionRepo, NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock(), 24*time.Hour, ReapPurge, false)
+
+	report, err := reaper.Reap(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Reap() error = %v", err)
+	}
+	if len(purged) != 1 || purged[0].ID != "idle" || purged[0].IsActive || len(purged[0].ShareTokens) != 0 {
+		t.Fatalf("Reap() purged = %+v, want the idle session inactive without share tokens", purged)
+	}
+	if len(report.SessionIDs) != 1 || report.Failed != 1 {
+		t.Fatalf("Reap() report = %+v, want one purged and one failed", report)
+	}
+}
+
+func TestSessionReaper_DryRun(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	sessionRepo := &mocks.SessionRepository{
+		GetExpiredFunc: func(ctx context.Context, at time.Time, ttl time.Duration) ([]*domain.Session, error) {
+			return newExpiredSessions(now), nil
+		},
+		UpdateFunc: func(ctx context.Context, session *domain.Session) error {
+			t.Errorf("Update(%s) called in a dry run", session.ID)
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			t.Errorf("Delete(%s) called in a dry run", id)
+			return nil
+		},
+	}
+	auditRepo := &mocks.AuditRepository{
+		AppendFunc: func(ctx context.Context, entry *domain.AuditEntry) error {
+			t.Errorf("Append(%s) called in a dry run", entry.Operation)
+			return nil
+		},
+	}
+
+	for _, mode := range []ReapMode{ReapDeactivate, ReapPurge} {
+		reaper := NewSessionReaper(sessionRepo, NewAuditService(auditRepo, &mocks.Transactor{}, newTestClock()), newTestClock(), 24*time.Hour, mode, true)
+
+		report, err := reaper.Reap(context.Background(), now)
+		if err != nil {
+			t.Fatalf("Reap() error = %v", err)
+		}
+		if !report.DryRun || len(report.SessionIDs) != 1 {
+			t.Errorf("Reap(%s) report = %+v, want one session", mode, report)
+		}
+	}
+}
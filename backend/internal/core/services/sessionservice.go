@@ -2,6 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"time"
 
 	"brew/internal/core/domain"
@@ -9,13 +13,33 @@ import (
 	"brew/internal/utils/logger"
 )
 
+// randomTokenBytes is the entropy of share and transfer tokens; 256 bits
+// cannot be guessed even by someone enumerating links.
+const randomTokenBytes = 32
+
+// lastAccessedResolution is far below any sensible session TTL, so skipping
+// writes within it never makes the reaper expire a session in use.
+const lastAccessedResolution = time.Minute
+
+// sessionUpdateAttempts bounds how often an edit is reapplied after losing
+// a race with another edit of the same session.
+const sessionUpdateAttempts = 3
+
 type SessionService struct {
-	sessionRepo ports.SessionRepository
+	sessionRepo  ports.SessionRepository
+	auditService *AuditService
+	clock        ports.Clock
 }
 
-func NewSessionService(sessionRepo ports.SessionRepository) *SessionService {
+func NewSessionService(
+	sessionRepo ports.SessionRepository,
+	auditService *AuditService,
+	clock ports.Clock,
+) *SessionService {
 	return &SessionService{
-		sessionRepo: sessionRepo,
+		sessionRepo:  sessionRepo,
+		auditService: auditService,
+		clock:        clock,
 	}
 }
 
@@ -25,14 +49,24 @@ func (s *SessionService) CreateSession(
 ) (*domain.Session, error) {
 	logger.Debug("Creating session", "id", id)
 
+	if err := domain.ValidateSessionID(id); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
 	session := &domain.Session{
 		ID:           id,
-		CreatedAt:    time.Now(),
-		LastAccessed: time.Now(),
+		CreatedAt:    now,
+		LastAccessed: now,
 		IsActive:     true,
 	}
 
-	err := s.sessionRepo.Save(ctx, session)
+	err := s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Save(ctx, session); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditSessionCreated, id, "", "")
+	})
 	if err != nil {
 		logger.Error("Failed to save session", "error", err, "id", id)
 		return nil, err
@@ -55,7 +89,13 @@ func (s *SessionService) UpdateSession(
 	session *domain.Session,
 ) error {
 	logger.Debug("Updating session", "id", session.ID)
-	return s.sessionRepo.Update(ctx, session)
+
+	return s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditSessionUpdated, session.ID, "", "")
+	})
 }
 
 func (s *SessionService) DeleteSession(
@@ -63,9 +103,18 @@ func (s *SessionService) DeleteSession(
 	id string,
 ) error {
 	logger.Debug("Deleting session", "id", id)
-	return s.sessionRepo.Delete(ctx, id)
+
+	return s.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditSessionDeleted, id, "", "")
+	})
 }
 
+// UpdateLastAccessed is bookkeeping rather than an edit and is not audited.
+// It is called on every request, so a session touched within
+// lastAccessedResolution is not written again.
 func (s *SessionService) UpdateLastAccessed(
 	ctx context.Context,
 	id string,
@@ -78,6 +127,209 @@ func (s *SessionService) UpdateLastAccessed(
 		return err
 	}
 
-	session.LastAccessed = time.Now()
-	return s.sessionRepo.Update(ctx, session)
+	now := s.clock.Now()
+	if now.Sub(session.LastAccessed) < lastAccessedResolution {
+		return nil
+	}
+	return s.sessionRepo.Touch(ctx, id, now)
+}
+
+// CreateShareToken lets the owner hand out access to the session's jars.
+// A non-positive ttl issues a token that lives until it is revoked.
+func (s *SessionService) CreateShareToken(
+	ctx context.Context,
+	sessionID string,
+	scope domain.ShareScope,
+	ttl time.Duration,
+) (*domain.ShareToken, error) {
+	logger.Debug("Creating share token", "session_id", sessionID, "scope", scope)
+
+	if err := requireOwner(ctx, "issue share tokens"); err != nil {
+		return nil, err
+	}
+	if !scope.IsKnown() {
+		return nil, domain.InvalidField("scope", fmt.Sprintf("must be %s or %s", domain.ReadOnlyScope, domain.ReadWriteScope))
+	}
+
+	value, err := newRandomToken()
+	if err != nil {
+		logger.Error("Failed to generate share token", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	token := domain.ShareToken{
+		Token:     value,
+		Scope:     scope,
+		CreatedAt: now,
+		IsActive:  true,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		token.ExpiresAt = &expiresAt
+	}
+
+	err = s.auditService.transact(ctx, func(ctx context.Context) error {
+		_, err := s.editSession(ctx, sessionID, func(session *domain.Session) error {
+			if !session.IsActive {
+				return fmt.Errorf("session %s: %w", sessionID, domain.ErrSessionInactive)
+			}
+			session.ShareTokens = append(session.ShareTokens, token)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditShareTokenCreated, sessionID, "", "")
+	})
+	if err != nil {
+		logger.Error("Failed to save share token", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	logger.Debug("Share token created successfully", "session_id", sessionID, "scope", scope)
+	return &token, nil
+}
+
+// ResolveShareToken tells what a presented token grants. Unknown, revoked
+// and expired tokens are all forbidden so a caller cannot probe which
+// tokens once existed.
+func (s *SessionService) ResolveShareToken(
+	ctx context.Context,
+	value string,
+) (*domain.ShareGrant, error) {
+	logger.Debug("Resolving share token")
+
+	session, err := s.sessionRepo.GetByShareToken(ctx, value)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("share token is not valid: %w", domain.ErrForbidden)
+	}
+	if err != nil {
+		logger.Error("Failed to get session by share token", "error", err)
+		return nil, err
+	}
+
+	token := session.FindShareToken(value)
+	if token == nil || !token.IsUsable(s.clock.Now()) {
+		logger.Debug("Share token is revoked or expired", "session_id", session.ID)
+		return nil, fmt.Errorf("share token is revoked or expired: %w", domain.ErrForbidden)
+	}
+	if !session.IsActive {
+		logger.Debug("Share token of an inactive session", "session_id", session.ID)
+		return nil, fmt.Errorf("session of share token: %w", domain.ErrSessionInactive)
+	}
+
+	return &domain.ShareGrant{
+		SessionID: session.ID,
+		Token:     token.Token,
+		Scope:     token.Scope,
+	}, nil
+}
+
+// ListShareTokens shows the owner every token issued, revoked ones included.
+func (s *SessionService) ListShareTokens(
+	ctx context.Context,
+	sessionID string,
+) ([]domain.ShareToken, error) {
+	logger.Debug("Listing share tokens", "session_id", sessionID)
+
+	if err := requireOwner(ctx, "list share tokens"); err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		logger.Error("Failed to get session for listing share tokens", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+	return session.ShareTokens, nil
+}
+
+// RevokeShareToken deactivates the token; it is kept so the owner can still
+// see what was shared.
+func (s *SessionService) RevokeShareToken(
+	ctx context.Context,
+	sessionID string,
+	value string,
+) error {
+	logger.Debug("Revoking share token", "session_id", sessionID)
+
+	if err := requireOwner(ctx, "revoke share tokens"); err != nil {
+		return err
+	}
+
+	err := s.auditService.transact(ctx, func(ctx context.Context) error {
+		_, err := s.editSession(ctx, sessionID, func(session *domain.Session) error {
+			token := session.FindShareToken(value)
+			if token == nil {
+				return fmt.Errorf("share token of session %s: %w", sessionID, domain.ErrNotFound)
+			}
+			token.IsActive = false
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, domain.AuditShareTokenRevoked, sessionID, "", "")
+	})
+	if err != nil {
+		logger.Error("Failed to revoke share token", "error", err, "session_id", sessionID)
+		return err
+	}
+
+	logger.Debug("Share token revoked successfully", "session_id", sessionID)
+	return nil
+}
+
+// ListAuditEntries shows the owner every edit made in the session, by
+// themselves or through share tokens.
+func (s *SessionService) ListAuditEntries(
+	ctx context.Context,
+	sessionID string,
+	pointer *string,
+	limit int,
+) (*ports.PaginatedResult[*domain.AuditEntry], error) {
+	logger.Debug("Listing session audit entries", "session_id", sessionID, "limit", limit)
+
+	if err := requireOwner(ctx, "read the audit log"); err != nil {
+		return nil, err
+	}
+	return s.auditService.listBySession(ctx, sessionID, pointer, limit)
+}
+
+// editSession applies edit to the stored session and saves it. When another
+// edit got in first, edit is applied again to the fresh session rather than
+// overwriting it.
+func (s *SessionService) editSession(
+	ctx context.Context,
+	sessionID string,
+	edit func(session *domain.Session) error,
+) (*domain.Session, error) {
+	for attempt := 1; ; attempt++ {
+		session, err := s.sessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if err := edit(session); err != nil {
+			return nil, err
+		}
+
+		err = s.sessionRepo.Update(ctx, session)
+		if errors.Is(err, domain.ErrConflict) && attempt < sessionUpdateAttempts {
+			logger.Debug("Session changed concurrently, retrying", "session_id", sessionID, "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return session, nil
+	}
+}
+
+func newRandomToken() (string, error) {
+	buf := make([]byte, randomTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
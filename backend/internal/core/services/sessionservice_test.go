@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports/mocks"
+)
+
+// newShareSessionRepository keeps a single session in memory so issued
+// tokens can be resolved again.
+func newShareSessionRepository(session *domain.Session) *mocks.SessionRepository {
+	return &mocks.SessionRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Session, error) {
+			if id != session.ID {
+				return nil, fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+			}
+			clone := *session
+			clone.ShareTokens = append([]domain.ShareToken(nil), session.ShareTokens...)
+			return &clone, nil
+		},
+		GetByShareTokenFunc: func(ctx context.Context, token string) (*domain.Session, error) {
+			if session.FindShareToken(token) == nil {
+				return nil, fmt.Errorf("share token: %w", domain.ErrNotFound)
+			}
+			return session, nil
+		},
+		UpdateFunc: func(ctx context.Context, updated *domain.Session) error {
+			updated.Version++
+			*session = *updated
+			return nil
+		},
+	}
+}
+
+func TestSessionService_ShareToken_CreateAndResolve(t *testing.T) {
+	service := NewSessionService(newShareSessionRepository(&domain.Session{ID: "session-1", IsActive: true}), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+	ctx := context.Background()
+
+	token, err := service.CreateShareToken(ctx, "session-1", domain.ReadWriteScope, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateShareToken() error = %v", err)
+	}
+	if len(token.Token) != 43 || token.ExpiresAt == nil || !token.IsActive {
+		t.Fatalf("CreateShareToken() = %+v", token)
+	}
+
+	other, err := service.CreateShareToken(ctx, "session-1", domain.ReadOnlyScope, 0)
+	if err != nil {
+		t.Fatalf("CreateShareToken() error = %v", err)
+	}
+	if other.Token == token.Token || other.ExpiresAt != nil {
+		t.Fatalf("CreateShareToken() = %+v, want a distinct token without expiry", other)
+	}
+
+	grant, err := service.ResolveShareToken(ctx, token.Token)
+	if err != nil {
+		t.Fatalf("ResolveShareToken() error = %v", err)
+	}
+	if grant.SessionID != "session-1" || grant.Scope != domain.ReadWriteScope || !grant.CanWrite() {
+		t.Errorf("ResolveShareToken() = %+v", grant)
+	}
+}
+
+func TestSessionService_ShareToken_RejectsUnusableTokens(t *testing.T) {
+	past := testNow.Add(-time.Minute)
+	session := &domain.Session{
+		ID:       "session-1",
+		IsActive: true,
+		ShareTokens: []domain.ShareToken{
+			{Token: "expired", Scope: domain.ReadOnlyScope, ExpiresAt: &past, IsActive: true},
+			{Token: "revoked", Scope: domain.ReadOnlyScope, IsActive: false},
+		},
+	}
+	service := NewSessionService(newShareSessionRepository(session), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+
+	for _, token := range []string{"expired", "revoked", "unknown"} {
+		if _, err := service.ResolveShareToken(context.Background(), token); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("ResolveShareToken(%s) error = %v, want ErrForbidden", token, err)
+		}
+	}
+}
+
+func TestSessionService_ShareToken_Revoke(t *testing.T) {
+	service := NewSessionService(newShareSessionRepository(&domain.Session{ID: "session-1", IsActive: true}), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+	ctx := context.Background()
+
+	token, err := service.CreateShareToken(ctx, "session-1", domain.ReadOnlyScope, 0)
+	if err != nil {
+		t.Fatalf("CreateShareToken() error = %v", err)
+	}
+	if err := service.RevokeShareToken(ctx, "session-1", token.Token); err != nil {
+		t.Fatalf("RevokeShareToken() error = %v", err)
+	}
+	if _, err := service.ResolveShareToken(ctx, token.Token); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ResolveShareToken() error = %v, want ErrForbidden", err)
+	}
+	if err := service.RevokeShareToken(ctx, "session-1", "unknown"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RevokeShareToken() error = %v, want ErrNotFound", err)
+	}
+
+	tokens, err := service.ListShareTokens(ctx, "session-1")
+	if err != nil || len(tokens) != 1 || tokens[0].IsActive {
+		t.Errorf("ListShareTokens() = %+v, %v, want the revoked token", tokens, err)
+	}
+}
+
+func TestSessionService_ShareToken_RetriesConcurrentEdit(t *testing.T) {
+	session := &domain.Session{ID: "session-1", IsActive: true}
+	repo := newShareSessionRepository(session)
+	update := repo.UpdateFunc
+	conflicts := 1
+	repo.UpdateFunc = func(ctx context.Context, s *domain.Session) error {
+		if conflicts > 0 {
+			conflicts--
+			return fmt.Errorf("session %s: %w", s.ID, domain.ErrConflict)
+		}
+		return update(ctx, s)
+	}
+	service := NewSessionService(repo, NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+
+	if _, err := service.CreateShareToken(context.Background(), "session-1", domain.ReadOnlyScope, 0); err != nil {
+		t.Fatalf("CreateShareToken() error = %v, want the edit reapplied", err)
+	}
+	if len(session.ShareTokens) != 1 {
+		t.Fatalf("session ShareTokens = %+v, want one token", session.ShareTokens)
+	}
+}
+
+func TestSessionService_ShareToken_OwnerOnly(t *testing.T) {
+	service := NewSessionService(newShareSessionRepository(&domain.Session{ID: "session-1", IsActive: true}), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+	ctx := WithShareGrant(context.Background(), &domain.ShareGrant{SessionID: "session-1", Scope: domain.ReadWriteScope})
+
+	if _, err := service.CreateShareToken(ctx, "session-1", domain.ReadOnlyScope, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateShareToken() error = %v, want ErrForbidden", err)
+	}
+	if err := service.RevokeShareToken(ctx, "session-1", "any"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("RevokeShareToken() error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestSessionService_CreateShareToken_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewSessionService(newShareSessionRepository(&domain.Session{ID: "session-1", IsActive: true}), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+	if _, err := service.CreateShareToken(ctx, "session-1", "admin", 0); !errors.Is(err, domain.ErrInvalid) {
+		t.Errorf("CreateShareToken() error = %v, want ErrInvalid", err)
+	}
+
+	inactive := NewSessionService(newShareSessionRepository(&domain.Session{ID: "session-1"}), NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()), newTestClock())
+	if _, err := inactive.CreateShareToken(ctx, "session-1", domain.ReadOnlyScope, 0); !errors.Is(err, domain.ErrSessionInactive) {
+		t.Errorf("CreateShareToken() error = %v, want ErrSessionInactive", err)
+	}
+}
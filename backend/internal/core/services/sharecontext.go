@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"brew/internal/core/domain"
+	"brew/internal/utils/logger"
+)
+
+type shareGrantKey struct{}
+
+// WithShareGrant marks the request as coming from a share token holder
+// rather than the session owner.
+func WithShareGrant(ctx context.Context, grant *domain.ShareGrant) context.Context {
+	return context.WithValue(ctx, shareGrantKey{}, grant)
+}
+
+// ShareGrantFrom returns the grant stored by WithShareGrant, or nil when the
+// caller is the session owner.
+func ShareGrantFrom(ctx context.Context) *domain.ShareGrant {
+	grant, _ := ctx.Value(shareGrantKey{}).(*domain.ShareGrant)
+	return grant
+}
+
+// requireReadAccess keeps a share token holder inside the session that
+// issued the token.
+func requireReadAccess(ctx context.Context, sessionID string) error {
+	grant := ShareGrantFrom(ctx)
+	if grant != nil && grant.SessionID != sessionID {
+		logger.Debug("Share token belongs to another session", "session_id", sessionID)
+		return fmt.Errorf("share token of another session: %w", domain.ErrForbidden)
+	}
+	return nil
+}
+
+// requireWriteAccess additionally rejects writes made through a read-only
+// share token.
+func requireWriteAccess(ctx context.Context, sessionID string) error {
+	if err := requireReadAccess(ctx, sessionID); err != nil {
+		return err
+	}
+	grant := ShareGrantFrom(ctx)
+	if grant != nil && !grant.CanWrite() {
+		logger.Debug("Share token is read-only", "session_id", sessionID)
+		return fmt.Errorf("share token is %s: %w", grant.Scope, domain.ErrForbidden)
+	}
+	return nil
+}
+
+// requireOwner keeps share token holders from managing the session itself,
+// e.g. issuing further tokens. action completes "only the session owner
+// can ..." in the error.
+func requireOwner(ctx context.Context, action string) error {
+	if ShareGrantFrom(ctx) != nil {
+		logger.Debug("Share token used for an owner action", "action", action)
+		return fmt.Errorf("only the session owner can %s: %w", action, domain.ErrForbidden)
+	}
+	return nil
+}
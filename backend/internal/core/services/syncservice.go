@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+// syncClaimTimeout is how long an operation may stay pending before another
+// sync call may take it over. Claims are stored in the transaction applying
+// the operation, so one is only left behind by storage that cannot roll
+// back and failed to release it.
+const syncClaimTimeout = 5 * time.Minute
+
+// SyncService applies edits a client queued while offline. Every operation
+// carries a client-chosen UUID and its outcome is remembered, so a client
+// that lost the response can send the whole batch again safely.
+type SyncService struct {
+	syncRepo        ports.SyncRepository
+	brewService     *BrewService
+	timelineService *TimelineService
+	qualityService  *QualityService
+}
+
+func NewSyncService(
+	syncRepo ports.SyncRepository,
+	brewService *BrewService,
+	timelineService *TimelineService,
+	qualityService *QualityService,
+) *SyncService {
+	return &SyncService{
+		syncRepo:        syncRepo,
+		brewService:     brewService,
+		timelineService: timelineService,
+		qualityService:  qualityService,
+	}
+}
+
+// Sync applies operations in order and returns up to limit changes made in
+// the session after cursor, which is the Cursor of the previous batch or 0.
+func (s *SyncService) Sync(
+	ctx context.Context,
+	sessionID string,
+	operations []*domain.SyncOperation,
+	cursor int64,
+	limit int,
+) (*domain.SyncBatch, error) {
+	logger.Debug("Syncing session", "session_id", sessionID, "operations", len(operations), "cursor", cursor)
+
+	if len(operations) > 0 {
+		if err := requireWriteAccess(ctx, sessionID); err != nil {
+			return nil, err
+		}
+	} else if err := requireReadAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if err := validateSyncOperations(operations); err != nil {
+		return nil, err
+	}
+	if cursor < 0 {
+		return nil, domain.InvalidField("cursor", "must not be negative")
+	}
+	if _, err := s.brewService.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.SyncResult, 0, len(operations))
+	for _, operation := range operations {
+		result, err := s.applyOnce(ctx, sessionID, operation)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	// One extra entry tells whether the client should sync again right away.
+	changes, err := s.brewService.auditService.since(ctx, sessionID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	batch := &domain.SyncBatch{Results: results, Cursor: cursor}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		batch.HasMore = true
+	}
+	if len(changes) > 0 {
+		batch.Cursor = changes[len(changes)-1].Seq
+	}
+	batch.Changes = changes
+
+	logger.Debug("Session synced successfully", "session_id", sessionID, "cursor", batch.Cursor)
+	return batch, nil
+}
+
+func validateSyncOperations(operations []*domain.SyncOperation) error {
+	if len(operations) > domain.MaxSyncOperations {
+		return domain.InvalidField("operations", fmt.Sprintf("must not be more than %d", domain.MaxSyncOperations))
+	}
+	seen := make(map[string]bool, len(operations))
+	for _, operation := range operations {
+		if _, err := uuid.Parse(operation.ID); err != nil {
+			return fmt.Errorf("operation id %q is not a UUID: %w", operation.ID, domain.ErrInvalid)
+		}
+		if seen[operation.ID] {
+			return fmt.Errorf("operation %s is sent twice: %w", operation.ID, domain.ErrInvalid)
+		}
+		seen[operation.ID] = true
+	}
+	return nil
+}
+
+// applyOnce returns the remembered outcome of an operation already seen, or
+// applies it. The claim, the operation's writes and its outcome are stored
+// in one transaction, so a crash or a failed write in between never leaves
+// an operation half applied or applied without being remembered. Only
+// malformed batches are returned as errors; anything else that goes wrong
+// becomes part of the result.
+func (s *SyncService) applyOnce(
+	ctx context.Context,
+	sessionID string,
+	operation *domain.SyncOperation,
+) (*domain.SyncResult, error) {
+	now := s.brewService.clock.Now()
+	claim := &domain.SyncResult{
+		OperationID: operation.ID,
+		SessionID:   sessionID,
+		Type:        operation.Type,
+		Status:      domain.SyncPending,
+		ReceivedAt:  now,
+	}
+
+	var result *domain.SyncResult
+	var claimed bool
+	err := s.brewService.auditService.transact(ctx, func(ctx context.Context) error {
+		var stored *domain.SyncResult
+		var err error
+		stored, claimed, err = s.syncRepo.Claim(ctx, claim, now.Add(-syncClaimTimeout))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			// Either a replay, or another request is applying it right now.
+			result = stored
+			return nil
+		}
+
+		// A rejected operation is remembered, but none of its writes may be
+		// kept, so it runs in a nested transaction of its own.
+		var entityID string
+		err = s.brewService.auditService.transact(ctx, func(ctx context.Context) error {
+			var err error
+			entityID, err = s.apply(ctx, sessionID, operation)
+			return err
+		})
+
+		claim.Status = domain.SyncApplied
+		claim.EntityID = entityID
+		if err != nil {
+			if errors.Is(err, domain.ErrConflict) || !isDomainError(err) {
+				return err
+			}
+			claim.Status = domain.SyncRejected
+			claim.Error = err.Error()
+		}
+		if err := s.syncRepo.Complete(ctx, claim); err != nil {
+			return err
+		}
+		result = claim
+		return nil
+	})
+	if err == nil {
+		return result, nil
+	}
+
+	// Rolling back the transaction dropped the claim too. Releasing it
+	// once more is a no-op then, but makes sure a claim of a failed apply
+	// never outlives this request.
+	if claimed {
+		if err := s.syncRepo.Release(ctx, sessionID, operation.ID); err != nil {
+			logger.Error("Failed to release sync operation", "error", err, "operation_id", operation.ID)
+		}
+	}
+	claim.EntityID = ""
+	if errors.Is(err, domain.ErrConflict) {
+		return s.conflict(ctx, sessionID, operation, claim, err), nil
+	}
+
+	logger.Error("Failed to apply sync operation", "error", err, "operation_id", operation.ID)
+	claim.Status = domain.SyncFailed
+	claim.Error = "temporary failure, send the operation again"
+	return claim, nil
+}
+
+// conflict reports an edit made offline against a jar that changed since.
+// It is not remembered, so the client can rebase the edit on the jar's
+// current version and send the same operation again.
+func (s *SyncService) conflict(
+	ctx context.Context,
+	sessionID string,
+	operation *domain.SyncOperation,
+	claim *domain.SyncResult,
+	err error,
+) *domain.SyncResult {
+	logger.Debug("Sync operation conflicts with the jar", "error", err, "operation_id", operation.ID)
+
+	claim.Status = domain.SyncConflict
+	claim.Error = err.Error()
+	brewID, err := s.resolve(ctx, sessionID, operation.BrewID)
+	if err != nil || brewID == "" {
+		return claim
+	}
+	brew, err := s.brewService.GetBrew(ctx, brewID, sessionID)
+	if err != nil {
+		logger.Error("Failed to get brew of conflicting sync operation", "error", err, "brew_id", brewID)
+		return claim
+	}
+	claim.Version = &brew.Version
+	return claim
+}
+
+func (s *SyncService) apply(
+	ctx context.Context,
+	sessionID string,
+	operation *domain.SyncOperation,
+) (string, error) {
+	switch operation.Type {
+	case domain.SyncCreateBrew:
+		brew, err := s.brewService.CreateBrew(ctx, operation.Name, sessionID)
+		if err != nil {
+			return "", err
+		}
+		return brew.ID, nil
+
+	case domain.SyncRenameBrew:
+		brewID, err := s.resolve(ctx, sessionID, operation.BrewID)
+		if err != nil {
+			return "", err
+		}
+		brew, err := s.brewService.RenameBrew(ctx, brewID, sessionID, operation.Name, operation.Version)
+		if err != nil {
+			return "", err
+		}
+		return brew.ID, nil
+
+	case domain.SyncAddRecord:
+		brewID, err := s.resolve(ctx, sessionID, operation.BrewID)
+		if err != nil {
+			return "", err
+		}
+		record, err := s.brewService.AddRecord(ctx, brewID, sessionID, operation.Recipe)
+		if err != nil {
+			return "", err
+		}
+		return record.ID, nil
+
+	case domain.SyncAppendNote:
+		recordID, err := s.resolve(ctx, sessionID, operation.RecordID)
+		if err != nil {
+			return "", err
+		}
+		note, err := s.brewService.AppendNote(ctx, recordID, sessionID, operation.Text)
+		if err != nil {
+			return "", err
+		}
+		return note.ID, nil
+
+	case domain.SyncAddTimelineEvent:
+		brewID, err := s.resolve(ctx, sessionID, operation.BrewID)
+		if err != nil {
+			return "", err
+		}
+		event, err := s.timelineService.AddEvent(ctx, brewID, sessionID, operation.EventType, operation.Title, operation.At)
+		if err != nil {
+			return "", err
+		}
+		return event.ID, nil
+
+	case domain.SyncCompleteTimelineEvent:
+		eventID, err := s.resolve(ctx, sessionID, operation.EventID)
+		if err != nil {
+			return "", err
+		}
+		event, err := s.timelineService.CompleteEvent(ctx, eventID, sessionID)
+		if err != nil {
+			return "", err
+		}
+		return event.ID, nil
+
+	case domain.SyncAddEvaluation:
+		recordID, err := s.resolve(ctx, sessionID, operation.RecordID)
+		if err != nil {
+			return "", err
+		}
+		evaluation, err := s.qualityService.AddEvaluation(
+			ctx,
+			recordID,
+			sessionID,
+			operation.Score,
+			operation.Notes,
+			operation.Suggestions,
+		)
+		if err != nil {
+			return "", err
+		}
+		return evaluation.ID, nil
+
+	default:
+		return "", fmt.Errorf("sync operation type %q: %w", operation.Type, domain.ErrInvalid)
+	}
+}
+
+// resolve turns an "op:<id>" reference into the ID of the entity that
+// operation created; any other value is already an entity ID.
+func (s *SyncService) resolve(ctx context.Context, sessionID string, value string) (string, error) {
+	operationID, ok := domain.SyncReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	result, err := s.syncRepo.Get(ctx, sessionID, operationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", fmt.Errorf("referenced operation %s is unknown: %w", operationID, domain.ErrInvalid)
+	}
+	if err != nil {
+		return "", err
+	}
+	if result.Status == domain.SyncPending {
+		// Not a domain error: the operation is released and may be resent.
+		return "", fmt.Errorf("referenced operation %s is still being applied", operationID)
+	}
+	if result.Status != domain.SyncApplied {
+		return "", fmt.Errorf("referenced operation %s was not applied: %w", operationID, domain.ErrInvalid)
+	}
+	return result.EntityID, nil
+}
+
+func isDomainError(err error) bool {
+	for _, target := range []error{
+		domain.ErrNotFound,
+		domain.ErrAlreadyExists,
+		domain.ErrForbidden,
+		domain.ErrSessionInactive,
+		domain.ErrImmutable,
+		domain.ErrInvalid,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
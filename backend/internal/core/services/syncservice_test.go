@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports/mocks"
+)
+
+func newSyncTestService(syncRepo *mocks.SyncRepository, brewRepo *mocks.BrewRepository) *SyncService {
+	brewService := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{
+			GenerateFunc: func(ctx context.Context, name string) (string, error) {
+				return "brew-1", nil
+			},
+		},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	return NewSyncService(
+		syncRepo,
+		brewService,
+		NewTimelineService(&mocks.TimelineRepository{}, brewService),
+		NewQualityService(&mocks.QualityRepository{}, &mocks.BrewRecordRepository{}, brewService),
+	)
+}
+
+func claimAlways(ctx context.Context, result *domain.SyncResult, staleBefore time.Time) (*domain.SyncResult, bool, error) {
+	return result, true, nil
+}
+
+func TestSyncService_Sync_RejectsMalformedBatch(t *testing.T) {
+	service := newSyncTestService(&mocks.SyncRepository{ClaimFunc: claimAlways}, &mocks.BrewRepository{})
+
+	duplicate := uuid.NewString()
+	tooMany := make([]*domain.SyncOperation, domain.MaxSyncOperations+1)
+	for i := range tooMany {
+		tooMany[i] = &domain.SyncOperation{ID: uuid.NewString(), Type: domain.SyncCreateBrew}
+	}
+
+	tests := []struct {
+		name       string
+		operations []*domain.SyncOperation
+		cursor     int64
+	}{
+		{name: "too many operations", operations: tooMany},
+		{name: "id is not a UUID", operations: []*domain.SyncOperation{{ID: "op-1", Type: domain.SyncCreateBrew}}},
+		{
+			name: "id sent twice",
+			operations: []*domain.SyncOperation{
+				{ID: duplicate, Type: domain.SyncCreateBrew},
+				{ID: duplicate, Type: domain.SyncCreateBrew},
+			},
+		},
+		{name: "negative cursor", cursor: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Sync(context.Background(), "session-1", tt.operations, tt.cursor, 10)
+			if !errors.Is(err, domain.ErrInvalid) {
+				t.Fatalf("Sync() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestSyncService_Sync_ReplaysStoredOutcome(t *testing.T) {
+	operationID := uuid.NewString()
+	syncRepo := &mocks.SyncRepository{
+		ClaimFunc: func(ctx context.Context, result *domain.SyncResult, staleBefore time.Time) (*domain.SyncResult, bool, error) {
+			return &domain.SyncResult{
+				OperationID: result.OperationID,
+				SessionID:   result.SessionID,
+				Type:        domain.SyncCreateBrew,
+				Status:      domain.SyncApplied,
+				EntityID:    "brew-1",
+			}, false, nil
+		},
+	}
+	brewRepo := &mocks.BrewRepository{
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			t.Fatal("Save() called for an operation that was already applied")
+			return nil
+		},
+	}
+	service := newSyncTestService(syncRepo, brewRepo)
+
+	batch, err := service.Sync(
+		context.Background(),
+		"session-1",
+		[]*domain.SyncOperation{{ID: operationID, Type: domain.SyncCreateBrew, Name: "jar"}},
+		0,
+		10,
+	)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(batch.Results) != 1 || batch.Results[0].Status != domain.SyncApplied || batch.Results[0].EntityID != "brew-1" {
+		t.Fatalf("Sync() results = %+v, want the stored outcome", batch.Results)
+	}
+}
+
+func TestSyncService_Sync_RejectionIsStored(t *testing.T) {
+	var completed *domain.SyncResult
+	syncRepo := &mocks.SyncRepository{
+		ClaimFunc: claimAlways,
+		CompleteFunc: func(ctx context.Context, result *domain.SyncResult) error {
+			completed = result
+			return nil
+		},
+		GetFunc: func(ctx context.Context, sessionID string, operationID string) (*domain.SyncResult, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+	service := newSyncTestService(syncRepo, &mocks.BrewRepository{})
+
+	batch, err := service.Sync(
+		context.Background(),
+		"session-1",
+		[]*domain.SyncOperation{{ID: uuid.NewString(), Type: domain.SyncAddRecord, BrewID: "op:" + uuid.NewString()}},
+		0,
+		10,
+	)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	result := batch.Results[0]
+	if result.Status != domain.SyncRejected || !strings.Contains(result.Error, "unknown") {
+		t.Fatalf("Sync() result = %+v, want rejected for an unknown reference", result)
+	}
+	if completed != result {
+		t.Fatal("Sync() did not store the rejection")
+	}
+}
+
+func TestSyncService_Sync_TransientFailureReleasesClaim(t *testing.T) {
+	var released string
+	syncRepo := &mocks.SyncRepository{
+		ClaimFunc: claimAlways,
+		CompleteFunc: func(ctx context.Context, result *domain.SyncResult) error {
+			t.Fatal("Complete() called for an operation that failed transiently")
+			return nil
+		},
+		ReleaseFunc: func(ctx context.Context, sessionID string, operationID string) error {
+			released = operationID
+			return nil
+		},
+	}
+	brewRepo := &mocks.BrewRepository{
+		SaveFunc: func(ctx context.Context, brew *domain.Brew) error {
+			return errors.New("disk full")
+		},
+	}
+	service := newSyncTestService(syncRepo, brewRepo)
+
+	operationID := uuid.NewString()
+	batch, err := service.Sync(
+		context.Background(),
+		"session-1",
+		[]*domain.SyncOperation{{ID: operationID, Type: domain.SyncCreateBrew, Name: "jar"}},
+		0,
+		10,
+	)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if batch.Results[0].Status != domain.SyncFailed {
+		t.Fatalf("Sync() result = %+v, want failed", batch.Results[0])
+	}
+	if strings.Contains(batch.Results[0].Error, "disk full") {
+		t.Fatalf("Sync() result error = %q, leaks the internal error", batch.Results[0].Error)
+	}
+	if released != operationID {
+		t.Fatalf("Release() got %q, want %q", released, operationID)
+	}
+}
+
+func TestSyncService_Sync_ReadOnlyShareCannotWrite(t *testing.T) {
+	service := newSyncTestService(&mocks.SyncRepository{ClaimFunc: claimAlways}, &mocks.BrewRepository{})
+	ctx := WithShareGrant(context.Background(), &domain.ShareGrant{
+		SessionID: "session-1",
+		Token:     "token",
+		Scope:     domain.ReadOnlyScope,
+	})
+
+	_, err := service.Sync(ctx, "session-1", []*domain.SyncOperation{{ID: uuid.NewString(), Type: domain.SyncCreateBrew}}, 0, 10)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("Sync() error = %v, want ErrForbidden", err)
+	}
+	if _, err := service.Sync(ctx, "session-1", nil, 0, 10); err != nil {
+		t.Fatalf("Sync() without operations error = %v, want nil", err)
+	}
+}
+
+func TestSyncService_Sync_FailedCompleteKeepsNothing(t *testing.T) {
+	var released string
+	syncRepo := &mocks.SyncRepository{
+		ClaimFunc: claimAlways,
+		CompleteFunc: func(ctx context.Context, result *domain.SyncResult) error {
+			return errors.New("disk full")
+		},
+		ReleaseFunc: func(ctx context.Context, sessionID string, operationID string) error {
+			released = operationID
+			return nil
+		},
+	}
+	service := newSyncTestService(syncRepo, &mocks.BrewRepository{})
+	var rolledBack bool
+	service.brewService.auditService.transactor = &mocks.Transactor{
+		WithinTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			rolledBack = rolledBack || err != nil
+			return err
+		},
+	}
+
+	operationID := uuid.NewString()
+	batch, err := service.Sync(
+		context.Background(),
+		"session-1",
+		[]*domain.SyncOperation{{ID: operationID, Type: domain.SyncCreateBrew, Name: "jar"}},
+		0,
+		10,
+	)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result := batch.Results[0]; result.Status != domain.SyncFailed || result.EntityID != "" {
+		t.Fatalf("Sync() result = %+v, want failed", result)
+	}
+	if !rolledBack {
+		t.Fatal("jar kept although its operation was not remembered, want the transaction rolled back")
+	}
+	if released != operationID {
+		t.Fatalf("Release() got %q, want %q", released, operationID)
+	}
+}
+
+func TestSyncService_Sync_ConflictCarriesCurrentVersion(t *testing.T) {
+	var released string
+	syncRepo := &mocks.SyncRepository{
+		ClaimFunc: claimAlways,
+		CompleteFunc: func(ctx context.Context, result *domain.SyncResult) error {
+			t.Fatal("Complete() called for a conflicting operation")
+			return nil
+		},
+		ReleaseFunc: func(ctx context.Context, sessionID string, operationID string) error {
+			released = operationID
+			return nil
+		},
+	}
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, Name: "jar", SessionID: "session-1", Version: 3}, nil
+		},
+	}
+	service := newSyncTestService(syncRepo, brewRepo)
+
+	operationID := uuid.NewString()
+	outdated := int64(1)
+	batch, err := service.Sync(
+		context.Background(),
+		"session-1",
+		[]*domain.SyncOperation{{ID: operationID, Type: domain.SyncRenameBrew, BrewID: "brew-1", Name: "renamed", Version: &outdated}},
+		0,
+		10,
+	)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	result := batch.Results[0]
+	if result.Status != domain.SyncConflict || result.Version == nil || *result.Version != 3 {
+		t.Fatalf("Sync() result = %+v, want a conflict at version 3", result)
+	}
+	if released != operationID {
+		t.Fatalf("Release() got %q, want %q so the rebased edit can be sent again", released, operationID)
+	}
+}
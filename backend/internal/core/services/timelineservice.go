@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports"
+	"brew/internal/utils/logger"
+)
+
+type TimelineService struct {
+	timelineRepo ports.TimelineRepository
+	brewService  *BrewService
+}
+
+func NewTimelineService(
+	timelineRepo ports.TimelineRepository,
+	brewService *BrewService,
+) *TimelineService {
+	return &TimelineService{
+		timelineRepo: timelineRepo,
+		brewService:  brewService,
+	}
+}
+
+func (s *TimelineService) AddEvent(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+	eventType domain.TimelineEventType,
+	title string,
+	at time.Time,
+) (*domain.TimelineEvent, error) {
+	logger.Debug("Adding timeline event", "brew_id", brewID, "session_id", sessionID, "type", eventType)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if !eventType.IsKnown() {
+		return nil, domain.InvalidField("type", fmt.Sprintf("%q is not a timeline event type", eventType))
+	}
+	if eventType == domain.CustomEvent && strings.TrimSpace(title) == "" {
+		return nil, domain.InvalidField("title", "is required for custom events")
+	}
+	if at.IsZero() {
+		return nil, domain.InvalidField("at", "is required")
+	}
+
+	if _, err := s.brewService.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewTimelineEvent(uuid.NewString(), brewID, sessionID, eventType, title, at, s.brewService.clock.Now())
+
+	err := s.brewService.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.timelineRepo.Save(ctx, event); err != nil {
+			return err
+		}
+		s.refreshNextAction(ctx, brewID)
+		return s.brewService.auditService.record(ctx, domain.AuditTimelineAdded, sessionID, brewID, "")
+	})
+	if err != nil {
+		logger.Error("Failed to save timeline event", "error", err, "brew_id", brewID)
+		return nil, err
+	}
+
+	logger.Debug("Timeline event added successfully", "id", event.ID, "brew_id", brewID)
+	return event, nil
+}
+
+func (s *TimelineService) CompleteEvent(
+	ctx context.Context,
+	eventID string,
+	sessionID string,
+) (*domain.TimelineEvent, error) {
+	logger.Debug("Completing timeline event", "id", eventID, "session_id", sessionID)
+
+	if err := requireWriteAccess(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.brewService.requireActiveSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	event, err := s.timelineRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.brewService.GetBrew(ctx, event.BrewID, sessionID); err != nil {
+		return nil, err
+	}
+
+	if !event.IsPending() {
+		return event, nil
+	}
+	event.Complete(s.brewService.clock.Now())
+
+	err = s.brewService.auditService.transact(ctx, func(ctx context.Context) error {
+		if err := s.timelineRepo.Update(ctx, event); err != nil {
+			return err
+		}
+		s.refreshNextAction(ctx, event.BrewID)
+		return s.brewService.auditService.record(ctx, domain.AuditTimelineCompleted, sessionID, event.BrewID, "")
+	})
+	if err != nil {
+		logger.Error("Failed to complete timeline event", "error", err, "id", eventID)
+		return nil, err
+	}
+
+	logger.Debug("Timeline event completed successfully", "id", eventID)
+	return event, nil
+}
+
+func (s *TimelineService) ListEvents(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+) ([]*domain.TimelineEvent, error) {
+	logger.Debug("Listing timeline events", "brew_id", brewID, "session_id", sessionID)
+
+	if _, err := s.brewService.GetBrew(ctx, brewID, sessionID); err != nil {
+		return nil, err
+	}
+	return s.timelineRepo.GetByBrewID(ctx, brewID)
+}
+
+// NextAction returns what the brewer should do next with the jar, or nil
+// when its timeline has nothing pending.
+func (s *TimelineService) NextAction(
+	ctx context.Context,
+	brewID string,
+	sessionID string,
+) (*domain.NextAction, error) {
+	logger.Debug("Computing next action", "brew_id", brewID, "session_id", sessionID)
+
+	events, err := s.ListEvents(ctx, brewID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.ComputeNextAction(events, s.brewService.clock.Now())
+	if action != nil {
+		logger.Debug("Next action computed", "brew_id", brewID, "type", action.Type, "due_at", action.DueAt)
+	}
+	return action, nil
+}
+
+// refreshNextAction stores when the jar's next action is due so that jar
+// lists can sort by it. It is best effort: a stale due date only affects
+// the order of jar lists, so it never fails the event it follows.
+func (s *TimelineService) refreshNextAction(ctx context.Context, brewID string) {
+	events, err := s.timelineRepo.GetByBrewID(ctx, brewID)
+	if err != nil {
+		logger.Error("Failed to list timeline events for next action", "error", err, "brew_id", brewID)
+		return
+	}
+
+	var dueAt *time.Time
+	if action := domain.ComputeNextAction(events, s.brewService.clock.Now()); action != nil {
+		dueAt = &action.DueAt
+	}
+	if err := s.brewService.brewRepo.SetNextAction(ctx, brewID, dueAt); err != nil {
+		logger.Error("Failed to store next action", "error", err, "brew_id", brewID)
+	}
+}
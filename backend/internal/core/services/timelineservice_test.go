@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"brew/internal/core/domain"
+	"brew/internal/core/ports/mocks"
+)
+
+func newTimelineTestService(timelineRepo *mocks.TimelineRepository) *TimelineService {
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-1"}, nil
+		},
+	}
+	brewService := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	return NewTimelineService(timelineRepo, brewService)
+}
+
+func TestTimelineService_AddEvent_Success(t *testing.T) {
+	var saved *domain.TimelineEvent
+	service := newTimelineTestService(&mocks.TimelineRepository{
+		SaveFunc: func(ctx context.Context, event *domain.TimelineEvent) error {
+			saved = event
+			return nil
+		},
+	})
+
+	at := testNow
+	event, err := service.AddEvent(context.Background(), "brew-1", "session-1", domain.StartEvent, "", at)
+	if err != nil {
+		t.Fatalf("AddEvent() error = %v, want nil", err)
+	}
+	if saved != event {
+		t.Error("AddEvent() did not save the returned event")
+	}
+	if event.BrewID != "brew-1" || event.IsPending() {
+		t.Errorf("AddEvent() = %+v, want completed start event for brew-1", event)
+	}
+}
+
+func TestTimelineService_AddEvent_Invalid(t *testing.T) {
+	service := newTimelineTestService(&mocks.TimelineRepository{})
+
+	tests := []struct {
+		name      string
+		eventType domain.TimelineEventType
+		title     string
+		at        time.Time
+	}{
+		{name: "unknown type", eventType: "harvest-ish", at: testNow},
+		{name: "custom without title", eventType: domain.CustomEvent, at: testNow},
+		{name: "missing time", eventType: domain.RefillEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddEvent(context.Background(), "brew-1", "session-1", tt.eventType, tt.title, tt.at)
+			if !errors.Is(err, domain.ErrInvalid) {
+				t.Fatalf("AddEvent() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestTimelineService_AddEvent_OtherSessionNotFound(t *testing.T) {
+	service := newTimelineTestService(&mocks.TimelineRepository{})
+
+	_, err := service.AddEvent(context.Background(), "brew-1", "session-2", domain.RefillEvent, "", testNow)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("AddEvent() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTimelineService_NextAction_DerivesHarvest(t *testing.T) {
+	start := testNow.Add(-24 * time.Hour)
+	service := newTimelineTestService(&mocks.TimelineRepository{
+		GetByBrewIDFunc: func(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error) {
+			return []*domain.TimelineEvent{
+				domain.NewTimelineEvent("event-1", brewID, "session-1", domain.StartEvent, "", start, start),
+			}, nil
+		},
+	})
+
+	action, err := service.NextAction(context.Background(), "brew-1", "session-1")
+	if err != nil {
+		t.Fatalf("NextAction() error = %v, want nil", err)
+	}
+	if action == nil || action.Type != domain.EstimatedHarvestEvent {
+		t.Fatalf("NextAction() = %+v, want estimated harvest", action)
+	}
+	if want := start.Add(domain.DefaultFermentationPeriod); !action.DueAt.Equal(want) {
+		t.Errorf("NextAction().DueAt = %v, want %v", action.DueAt, want)
+	}
+}
+
+func TestTimelineService_AddEvent_StoresNextAction(t *testing.T) {
+	start := testNow.Add(-24 * time.Hour)
+	var storedID string
+	var storedAt *time.Time
+	brewRepo := &mocks.BrewRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Brew, error) {
+			return &domain.Brew{ID: id, SessionID: "session-1"}, nil
+		},
+		SetNextActionFunc: func(ctx context.Context, id string, at *time.Time) error {
+			storedID, storedAt = id, at
+			return nil
+		},
+	}
+	brewService := NewBrewService(
+		brewRepo,
+		&mocks.BrewRecordRepository{},
+		&mocks.TransferRepository{},
+		newActiveSessionRepository(),
+		&mocks.IdentifierGenerator{},
+		NewQRService(&mocks.QRCodeGenerator{}),
+		NewAuditService(&mocks.AuditRepository{}, &mocks.Transactor{}, newTestClock()),
+		NewSearchService(&mocks.SearchRepository{}),
+		newTestClock(),
+	)
+	service := NewTimelineService(&mocks.TimelineRepository{
+		GetByBrewIDFunc: func(ctx context.Context, brewID string) ([]*domain.TimelineEvent, error) {
+			return []*domain.TimelineEvent{
+				domain.NewTimelineEvent("event-1", brewID, "session-1", domain.StartEvent, "", start, start),
+			}, nil
+		},
+	}, brewService)
+
+	if _, err := service.AddEvent(context.Background(), "brew-1", "session-1", domain.StartEvent, "", start); err != nil {
+		t.Fatalf("AddEvent() error = %v, want nil", err)
+	}
+	want := start.Add(domain.DefaultFermentationPeriod)
+	if storedID != "brew-1" || storedAt == nil || !storedAt.Equal(want) {
+		t.Fatalf("SetNextAction(%q, %v), want brew-1 at %v", storedID, storedAt, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
@@ -18,8 +19,132 @@ func init() {
 	slog.SetDefault(slog.New(handler))
 }
 
+const (
+	defaultLogLevel               = "INFO"
+	defaultHTTPAddress            = ":8080"
+	defaultShutdownTimeoutSeconds = 10
+	defaultStorageDriver          = StorageDriverSQLite
+	defaultDatabasePath           = "brew.db"
+	defaultPublicURL              = "http://localhost:8080"
+	defaultSessionTTLHours        = 30 * 24
+	defaultReaperIntervalMinutes  = 60
+	defaultReaperMode             = ReaperModeDeactivate
+)
+
+const (
+	StorageDriverSQLite = "sqlite"
+	StorageDriverMemory = "memory"
+)
+
+const (
+	// ReaperModeDeactivate keeps expired sessions for the record but stops
+	// them and their share tokens from being used.
+	ReaperModeDeactivate = "deactivate"
+	// ReaperModePurge deletes the share tokens of expired sessions and keeps
+	// the sessions only as tombstones, so their IDs cannot be reused.
+	ReaperModePurge = "purge"
+)
+
 type Config struct {
-	LogLevel string `json:"log_level"`
+	LogLevel               string `json:"log_level"`
+	HTTPAddress            string `json:"http_address"`
+	ShutdownTimeoutSeconds int    `json:"shutdown_timeout_seconds"`
+	StorageDriver          string `json:"storage_driver"`
+	DatabasePath           string `json:"database_path"`
+	// PublicURL is where brewers reach the app; jar QR codes link into it.
+	PublicURL string `json:"public_url"`
+	// SessionTTLHours is how long a session may stay untouched before the
+	// reaper expires it.
+	SessionTTLHours       int    `json:"session_ttl_hours"`
+	ReaperIntervalMinutes int    `json:"reaper_interval_minutes"`
+	ReaperMode            string `json:"reaper_mode"`
+	// ReaperDryRun only reports what the reaper would expire.
+	ReaperDryRun bool `json:"reaper_dry_run"`
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		LogLevel:               defaultLogLevel,
+		HTTPAddress:            defaultHTTPAddress,
+		ShutdownTimeoutSeconds: defaultShutdownTimeoutSeconds,
+		StorageDriver:          defaultStorageDriver,
+		DatabasePath:           defaultDatabasePath,
+		PublicURL:              defaultPublicURL,
+		SessionTTLHours:        defaultSessionTTLHours,
+		ReaperIntervalMinutes:  defaultReaperIntervalMinutes,
+		ReaperMode:             defaultReaperMode,
+	}
+}
+
+func (c *Config) ShutdownTimeout() time.Duration {
+	return time.Duration(c.ShutdownTimeoutSeconds) * time.Second
+}
+
+func (c *Config) SessionTTL() time.Duration {
+	return time.Duration(c.SessionTTLHours) * time.Hour
+}
+
+func (c *Config) ReaperInterval() time.Duration {
+	return time.Duration(c.ReaperIntervalMinutes) * time.Minute
+}
+
+func (c *Config) applyDefaults() {
+	defaults := defaultConfig()
+	if c.LogLevel == "" {
+		c.LogLevel = defaults.LogLevel
+	}
+	if c.HTTPAddress == "" {
+		c.HTTPAddress = defaults.HTTPAddress
+	}
+	if c.ShutdownTimeoutSeconds <= 0 {
+		c.ShutdownTimeoutSeconds = defaults.ShutdownTimeoutSeconds
+	}
+	if c.StorageDriver == "" {
+		c.StorageDriver = defaults.StorageDriver
+	}
+	if c.DatabasePath == "" {
+		c.DatabasePath = defaults.DatabasePath
+	}
+	if c.PublicURL == "" {
+		c.PublicURL = defaults.PublicURL
+	}
+	if c.SessionTTLHours <= 0 {
+		c.SessionTTLHours = defaults.SessionTTLHours
+	}
+	if c.ReaperIntervalMinutes <= 0 {
+		c.ReaperIntervalMinutes = defaults.ReaperIntervalMinutes
+	}
+	if c.ReaperMode != ReaperModeDeactivate && c.ReaperMode != ReaperModePurge {
+		c.ReaperMode = defaults.ReaperMode
+	}
+}
+
+// Load reads the config file once, falling back to the defaults when it is
+// missing or malformed. Nothing is reloaded afterwards: only the log level
+// follows the file while running, through the logger's own ConfigWatcher.
+func Load(configPath string) *Config {
+	slog.Debug("Loading config from file", "path", configPath)
+
+	config, err := readConfig(configPath)
+	if err != nil {
+		slog.Error("Failed to load config file, using defaults", "error", err, "path", configPath)
+		return defaultConfig()
+	}
+	return config
+}
+
+func readConfig(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	config.applyDefaults()
+	return &config, nil
 }
 
 type WatcherFactory func() (*fsnotify.Watcher, error)
@@ -30,6 +155,9 @@ type ConfigWatcher struct {
 	mu             sync.RWMutex
 	cachedConfig   *Config
 	watcherFactory WatcherFactory
+	done           chan struct{}
+	stopOnce       sync.Once
+	wg             sync.WaitGroup
 }
 
 func NewConfigWatcher(configPath string) *ConfigWatcher {
@@ -38,6 +166,7 @@ func NewConfigWatcher(configPath string) *ConfigWatcher {
 		configPath:     configPath,
 		callbacks:      list.New(),
 		watcherFactory: fsnotify.NewWatcher,
+		done:           make(chan struct{}),
 	}
 	watcher.start()
 	return watcher
@@ -69,7 +198,7 @@ func (cw *ConfigWatcher) loadFromFile() *Config {
 	data, err := os.ReadFile(cw.configPath)
 	if err != nil {
 		slog.Error("Failed to read config file", "error", err, "path", cw.configPath)
-		config := &Config{LogLevel: "INFO"}
+		config := defaultConfig()
 		cw.mu.Lock()
 		cw.cachedConfig = config
 		cw.mu.Unlock()
@@ -80,13 +209,14 @@ func (cw *ConfigWatcher) loadFromFile() *Config {
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		slog.Error("Failed to parse config JSON", "error", err, "path", cw.configPath)
-		defaultConfig := &Config{LogLevel: "INFO"}
+		fallback := defaultConfig()
 		cw.mu.Lock()
-		cw.cachedConfig = defaultConfig
+		cw.cachedConfig = fallback
 		cw.mu.Unlock()
-		slog.Debug("Using default config after JSON parse error", "log_level", defaultConfig.LogLevel)
-		return defaultConfig
+		slog.Debug("Using default config after JSON parse error", "log_level", fallback.LogLevel)
+		return fallback
 	}
+	config.applyDefaults()
 
 	cw.mu.Lock()
 	oldConfig := cw.cachedConfig
@@ -104,7 +234,21 @@ func (cw *ConfigWatcher) loadFromFile() *Config {
 func (cw *ConfigWatcher) start() {
 	slog.Debug("Starting config watcher", "path", cw.configPath)
 	cw.loadFromFile()
-	go cw.watchConfig()
+	cw.wg.Add(1)
+	go func() {
+		defer cw.wg.Done()
+		cw.watchConfig()
+	}()
+}
+
+// Stop terminates the file watcher goroutine and waits for it to exit.
+// It is safe to call Stop more than once.
+func (cw *ConfigWatcher) Stop() {
+	cw.stopOnce.Do(func() {
+		slog.Debug("Stopping config watcher", "path", cw.configPath)
+		close(cw.done)
+	})
+	cw.wg.Wait()
 }
 
 func (cw *ConfigWatcher) watchConfig() {
@@ -133,6 +277,9 @@ func (cw *ConfigWatcher) watchConfig() {
 
 	for {
 		select {
+		case <-cw.done:
+			slog.Debug("Config watcher stopped", "path", cw.configPath)
+			return
 		case event, ok := <-watcher.Events:
 			if !ok {
 				slog.Debug("File watcher events channel closed", "path", cw.configPath)
@@ -17,6 +17,7 @@ func newTestConfigWatcher(configPath string) *ConfigWatcher {
 		configPath:     configPath,
 		callbacks:      list.New(),
 		watcherFactory: fsnotify.NewWatcher,
+		done:           make(chan struct{}),
 	}
 }
 
@@ -25,6 +26,7 @@ func newTestConfigWatcherWithFactory(configPath string, factory WatcherFactory)
 		configPath:     configPath,
 		callbacks:      list.New(),
 		watcherFactory: factory,
+		done:           make(chan struct{}),
 	}
 }
 
@@ -797,6 +799,69 @@ func TestConfigWatcher_WatchConfigIgnoreOtherOperations(t *testing.T) {
 	}
 }
 
+func TestConfigWatcher_Stop(t *testing.T) {
+	testFile := "test-stop.json"
+	defer os.Remove(testFile)
+
+	mockWatcher := newMockWatcher()
+	factory := func() (*fsnotify.Watcher, error) {
+		return &mockWatcher.Watcher, nil
+	}
+
+	watcher := newTestConfigWatcherWithFactory(testFile, factory)
+	watcher.start()
+
+	stopped := make(chan struct{})
+	go func() {
+		watcher.Stop()
+		watcher.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected Stop to terminate the watcher goroutine")
+	}
+}
+
+func TestConfigWatcher_ShutdownTimeoutDefault(t *testing.T) {
+	testFile := "test-shutdown-timeout.json"
+	defer os.Remove(testFile)
+
+	os.WriteFile(testFile, []byte(`{"log_level": "WARN"}`), 0644)
+
+	watcher := newTestConfigWatcher(testFile)
+
+	config := watcher.LoadConfig()
+	if config.ShutdownTimeout() != defaultShutdownTimeoutSeconds*time.Second {
+		t.Errorf("expected default shutdown timeout, got %s", config.ShutdownTimeout())
+	}
+}
+
+func TestConfigWatcher_ReaperDefaults(t *testing.T) {
+	testFile := "test-reaper-defaults.json"
+	defer os.Remove(testFile)
+
+	os.WriteFile(testFile, []byte(`{"reaper_mode": "shred", "reaper_dry_run": true}`), 0644)
+
+	watcher := newTestConfigWatcher(testFile)
+
+	config := watcher.LoadConfig()
+	if config.SessionTTL() != defaultSessionTTLHours*time.Hour {
+		t.Errorf("expected default session TTL, got %s", config.SessionTTL())
+	}
+	if config.ReaperInterval() != defaultReaperIntervalMinutes*time.Minute {
+		t.Errorf("expected default reaper interval, got %s", config.ReaperInterval())
+	}
+	if config.ReaperMode != ReaperModeDeactivate {
+		t.Errorf("expected unknown reaper mode to fall back to %s, got %s", ReaperModeDeactivate, config.ReaperMode)
+	}
+	if !config.ReaperDryRun {
+		t.Error("expected reaper dry run to be kept")
+	}
+}
+
 type MockWatcher struct {
 	fsnotify.Watcher
 	events chan fsnotify.Event
@@ -828,3 +893,29 @@ func (m *MockWatcher) Remove(name string) error {
 func (m *MockWatcher) Close() error {
 	return nil
 }
+
+func TestLoad(t *testing.T) {
+	testFile := "test-load.json"
+	defer os.Remove(testFile)
+
+	os.WriteFile(testFile, []byte(`{"http_address": ":9090", "reaper_mode": "purge"}`), 0644)
+
+	config := Load(testFile)
+	if config.HTTPAddress != ":9090" || config.ReaperMode != ReaperModePurge {
+		t.Errorf("expected values from the file, got %+v", config)
+	}
+	if config.LogLevel != defaultLogLevel {
+		t.Errorf("expected default log level, got %s", config.LogLevel)
+	}
+
+	for name, content := range map[string]string{"missing": "", "malformed": "{"} {
+		path := "test-load-" + name + ".json"
+		if content != "" {
+			os.WriteFile(path, []byte(content), 0644)
+			defer os.Remove(path)
+		}
+		if config := Load(path); *config != *defaultConfig() {
+			t.Errorf("%s file: expected defaults, got %+v", name, config)
+		}
+	}
+}
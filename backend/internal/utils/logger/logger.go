@@ -23,6 +23,11 @@ func init() {
 	updateLogger(initialConfig)
 }
 
+// Shutdown stops watching the config file for log level changes.
+func Shutdown() {
+	configWatcher.Stop()
+}
+
 func updateLogger(cfg *config.Config) {
 	level := parseLogLevel(cfg.LogLevel)
 	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{